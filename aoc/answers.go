@@ -0,0 +1,134 @@
+package aoc
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"strconv"
+)
+
+const ANSWERS_DEFAULT = "answers.json"
+
+// RecordedAnswers are the known correct answers for one input, inputs are
+// matched by their hash so that they can be moved or renamed freely
+type RecordedAnswers struct {
+	Input       string `json:"input"`
+	InputSha256 string `json:"input_sha256"`
+	// Maps the part number to its answer
+	Answers map[string]string `json:"answers"`
+}
+
+type AnswersFile struct {
+	Path    string
+	Entries []RecordedAnswers
+}
+
+// MismatchError is returned by Verify when an answer differs from the one
+// that was recorded
+type MismatchError struct {
+	Day      string
+	Part     int
+	Input    string
+	Expected string
+	Actual   string
+}
+
+func (m *MismatchError) Error() string {
+	return fmt.Sprintf("%s part %d (%s) does not match the recorded answer\n- %s\n+ %s",
+		m.Day, m.Part, m.Input, m.Expected, m.Actual)
+}
+
+// LoadAnswers reads the answers file for the day, a missing file is treated
+// as having no recorded answers
+func (d *Day) LoadAnswers(path string) (*AnswersFile, error) {
+	ret := &AnswersFile{Path: d.locate(path), Entries: make([]RecordedAnswers, 0)}
+
+	data, err := os.ReadFile(ret.Path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return ret, nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("Cannot read answers: %w", err)
+	}
+
+	if err = json.Unmarshal(data, &ret.Entries); err != nil {
+		return nil, fmt.Errorf("Cannot parse answers %s: %w", ret.Path, err)
+	}
+
+	return ret, nil
+}
+
+func (a *AnswersFile) find(inputSha256 string) *RecordedAnswers {
+	for i := range a.Entries {
+		if a.Entries[i].InputSha256 == inputSha256 {
+			return &a.Entries[i]
+		}
+	}
+
+	return nil
+}
+
+func (a *AnswersFile) Expected(inputSha256 string, part int) (string, bool) {
+	entry := a.find(inputSha256)
+	if entry == nil {
+		return "", false
+	}
+
+	answer, found := entry.Answers[strconv.Itoa(part)]
+	return answer, found
+}
+
+// Verify checks the result against the recorded answer, the returned bool is
+// false when there is no recorded answer to check against
+func (a *AnswersFile) Verify(result Result) (bool, error) {
+	record := result.Record()
+
+	expected, found := a.Expected(record.InputSha256, record.Part)
+	if !found {
+		return false, nil
+	}
+
+	if expected != record.Answer {
+		return true, &MismatchError{
+			Day:      record.Day,
+			Part:     record.Part,
+			Input:    record.Input,
+			Expected: expected,
+			Actual:   record.Answer,
+		}
+	}
+
+	return true, nil
+}
+
+func (a *AnswersFile) Record(result Result) {
+	record := result.Record()
+
+	entry := a.find(record.InputSha256)
+	if entry == nil {
+		a.Entries = append(a.Entries, RecordedAnswers{
+			Input:       record.Input,
+			InputSha256: record.InputSha256,
+			Answers:     make(map[string]string),
+		})
+		entry = &a.Entries[len(a.Entries)-1]
+	}
+
+	entry.Answers[strconv.Itoa(record.Part)] = record.Answer
+}
+
+func (a *AnswersFile) Save() error {
+	data, err := json.MarshalIndent(a.Entries, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err = os.WriteFile(a.Path, append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("Cannot write answers: %w", err)
+	}
+
+	return nil
+}
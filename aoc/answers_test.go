@@ -0,0 +1,121 @@
+package aoc
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+)
+
+func newTestResult(part int, answer any, sha256 string) Result {
+	return Result{Day: "day06", Part: part, Answer: answer, Input: "input.txt", InputSha256: sha256}
+}
+
+func TestLoadAnswersMissingFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ANSWERS_DEFAULT)
+
+	answers, err := newTestDay().LoadAnswers(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if answers.Path != path || len(answers.Entries) != 0 {
+		t.Errorf("Expected no answers from %s, got %v from %s", path, answers.Entries, answers.Path)
+	}
+}
+
+func TestLoadAnswersInvalid(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ANSWERS_DEFAULT)
+	writeFile(t, path, "{")
+
+	if _, err := newTestDay().LoadAnswers(path); err == nil {
+		t.Fatal("Expected an error")
+	}
+}
+
+func TestAnswersRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ANSWERS_DEFAULT)
+	day := newTestDay()
+
+	answers, err := day.LoadAnswers(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	answers.Record(newTestResult(1, 41, "abc"))
+	answers.Record(newTestResult(2, 6, "abc"))
+	answers.Record(newTestResult(1, 5000, "def"))
+	answers.Record(newTestResult(1, 42, "abc"))
+
+	if err = answers.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, err := day.LoadAnswers(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(loaded.Entries) != 2 {
+		t.Fatalf("Expected an entry per input, got %v", loaded.Entries)
+	}
+
+	tests := []struct {
+		sha256   string
+		part     int
+		expected string
+	}{
+		{sha256: "abc", part: 1, expected: "42"},
+		{sha256: "abc", part: 2, expected: "6"},
+		{sha256: "def", part: 1, expected: "5000"},
+	}
+
+	for _, test := range tests {
+		actual, found := loaded.Expected(test.sha256, test.part)
+		if !found || actual != test.expected {
+			t.Errorf("Expected %s for part %d of %s, got %q (found %v)", test.expected, test.part, test.sha256, actual, found)
+		}
+	}
+
+	if _, found := loaded.Expected("def", 2); found {
+		t.Error("Expected no answer for a part that was not recorded")
+	}
+}
+
+func TestVerify(t *testing.T) {
+	answers := &AnswersFile{Entries: make([]RecordedAnswers, 0)}
+	answers.Record(newTestResult(1, 41, "abc"))
+
+	checked, err := answers.Verify(newTestResult(1, 41, "abc"))
+	if !checked || err != nil {
+		t.Errorf("Expected a match, got %v %v", checked, err)
+	}
+
+	checked, err = answers.Verify(newTestResult(1, 41, "unknown"))
+	if checked || err != nil {
+		t.Errorf("Expected an unknown input to be unchecked, got %v %v", checked, err)
+	}
+
+	checked, err = answers.Verify(newTestResult(2, 6, "abc"))
+	if checked || err != nil {
+		t.Errorf("Expected an unrecorded part to be unchecked, got %v %v", checked, err)
+	}
+
+	checked, err = answers.Verify(newTestResult(1, 40, "abc"))
+	if !checked {
+		t.Error("Expected a mismatch to be checked")
+	}
+
+	var mismatch *MismatchError
+	if !errors.As(err, &mismatch) {
+		t.Fatalf("Expected a MismatchError, got %v", err)
+	}
+
+	if mismatch.Expected != "41" || mismatch.Actual != "40" {
+		t.Errorf("Expected 41 and 40, got %s and %s", mismatch.Expected, mismatch.Actual)
+	}
+
+	expected := "day06 part 1 (input.txt) does not match the recorded answer\n- 41\n+ 40"
+	if mismatch.Error() != expected {
+		t.Errorf("Expected %q, got %q", expected, mismatch.Error())
+	}
+}
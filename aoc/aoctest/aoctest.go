@@ -0,0 +1,58 @@
+// Package aoctest contains helpers for testing and benchmarking days
+package aoctest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/charmbracelet/log"
+	"github.com/djpiper28/advent-of-code-2024/aoc"
+)
+
+// BenchmarkInput is the input benchmarks are ran against, the real input is
+// used when present otherwise the example is used
+func BenchmarkInput(b *testing.B, day *aoc.Day) aoc.Input {
+	input, err := day.ReadInput(aoc.INPUT_DEFAULT)
+	if err == nil {
+		return input
+	}
+
+	input, err = day.ReadInput(aoc.INPUT_EXAMPLE_PREFIX + "example")
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	return input
+}
+
+// Quiet silences logging for the rest of the test, for fuzz targets that parse
+// many inputs which are mostly invalid
+func Quiet(tb testing.TB) {
+	level := log.GetLevel()
+	log.SetLevel(log.FatalLevel)
+	tb.Cleanup(func() { log.SetLevel(level) })
+}
+
+// Benchmark runs sub-benchmarks for parsing and both parts of the day, each
+// run is the same as a run of `--bench`
+func Benchmark(b *testing.B, day *aoc.Day) {
+	input := BenchmarkInput(b, day)
+	b.Cleanup(aoc.QuietLogging())
+
+	for _, part := range []int{0, 1, 2} {
+		b.Run(aoc.PhaseName(part), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				b.StopTimer()
+				err := day.BenchRun(context.Background(), input, part, func(f func() error) error {
+					b.StartTimer()
+					defer b.StopTimer()
+					return f()
+				})
+				if err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
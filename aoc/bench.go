@@ -0,0 +1,143 @@
+package aoc
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"slices"
+	"time"
+
+	"github.com/charmbracelet/log"
+)
+
+const PHASE_PARSE = "parse"
+
+// BenchStats summarises repeated runs of one phase, i.e: parsing or solving a
+// part
+type BenchStats struct {
+	Day    string        `json:"day"`
+	Phase  string        `json:"phase"`
+	Runs   int           `json:"runs"`
+	Min    time.Duration `json:"min_ns"`
+	Median time.Duration `json:"median_ns"`
+	P95    time.Duration `json:"p95_ns"`
+	// Heap allocations per run
+	Allocs uint64 `json:"allocs"`
+	Bytes  uint64 `json:"bytes"`
+}
+
+type measurement struct {
+	duration      time.Duration
+	allocs, bytes uint64
+}
+
+// measure times f and counts the heap allocations that it made
+func measure(f func() error) (measurement, error) {
+	var before, after runtime.MemStats
+
+	runtime.ReadMemStats(&before)
+	start := time.Now()
+	err := f()
+	duration := time.Since(start)
+	runtime.ReadMemStats(&after)
+
+	return measurement{
+		duration: duration,
+		allocs:   after.Mallocs - before.Mallocs,
+		bytes:    after.TotalAlloc - before.TotalAlloc,
+	}, err
+}
+
+func summarise(day, phase string, measurements []measurement) BenchStats {
+	durations := make([]time.Duration, len(measurements))
+	var allocs, bytes uint64
+
+	for i, m := range measurements {
+		durations[i] = m.duration
+		allocs += m.allocs
+		bytes += m.bytes
+	}
+
+	slices.Sort(durations)
+	runs := len(measurements)
+	p95 := (runs*95 + 99) / 100
+
+	return BenchStats{
+		Day:    day,
+		Phase:  phase,
+		Runs:   runs,
+		Min:    durations[0],
+		Median: durations[runs/2],
+		P95:    durations[p95-1],
+		Allocs: allocs / uint64(runs),
+		Bytes:  bytes / uint64(runs),
+	}
+}
+
+// PhaseName names the phase that BenchRun times for a part, part 0 is parsing
+func PhaseName(part int) string {
+	if part == 0 {
+		return PHASE_PARSE
+	}
+	return fmt.Sprintf("part%d", part)
+}
+
+// BenchRun makes one run of a phase, with timed wrapping only the work that is
+// measured. Part 0 is parsing, otherwise every run of a part is given freshly
+// parsed data as some solvers modify their input.
+func (d *Day) BenchRun(ctx context.Context, input Input, part int, timed func(f func() error) error) error {
+	if part == 0 {
+		return timed(func() error {
+			if _, err := d.parse(input.Data); err != nil {
+				return fmt.Errorf("Cannot parse data: %w", err)
+			}
+			return nil
+		})
+	}
+
+	data, err := d.parse(input.Data)
+	if err != nil {
+		return fmt.Errorf("Cannot parse data: %w", err)
+	}
+
+	return timed(func() error {
+		if _, err := d.Solve(ctx, part, data); err != nil {
+			return fmt.Errorf("Cannot solve part %d: %w", part, err)
+		}
+		return nil
+	})
+}
+
+// QuietLogging hides all but errors until the returned func is called, the
+// solvers log as they go which would otherwise be most of the time
+func QuietLogging() func() {
+	level := log.GetLevel()
+	log.SetLevel(log.ErrorLevel)
+	return func() { log.SetLevel(level) }
+}
+
+// Bench parses the input then solves each part runs times
+func (d *Day) Bench(ctx context.Context, input Input, parts []int, runs int) ([]BenchStats, error) {
+	if runs < 1 {
+		return nil, fmt.Errorf("Cannot benchmark %d runs", runs)
+	}
+
+	ret := make([]BenchStats, 0, len(parts)+1)
+	measurements := make([]measurement, runs)
+
+	for _, part := range append([]int{0}, parts...) {
+		for i := range measurements {
+			err := d.BenchRun(ctx, input, part, func(f func() error) error {
+				var err error
+				measurements[i], err = measure(f)
+				return err
+			})
+			if err != nil {
+				return nil, err
+			}
+		}
+		ret = append(ret, summarise(d.Name, PhaseName(part), measurements))
+	}
+
+	return ret, nil
+}
@@ -0,0 +1,39 @@
+package aoc
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSummarise(t *testing.T) {
+	tests := []struct {
+		runs     int
+		expected BenchStats
+	}{
+		{runs: 1, expected: BenchStats{Min: 1 * time.Millisecond, Median: 1 * time.Millisecond, P95: 1 * time.Millisecond, Allocs: 1, Bytes: 100}},
+		{runs: 2, expected: BenchStats{Min: 1 * time.Millisecond, Median: 2 * time.Millisecond, P95: 2 * time.Millisecond, Allocs: 1, Bytes: 150}},
+		{runs: 10, expected: BenchStats{Min: 1 * time.Millisecond, Median: 6 * time.Millisecond, P95: 10 * time.Millisecond, Allocs: 5, Bytes: 550}},
+		{runs: 20, expected: BenchStats{Min: 1 * time.Millisecond, Median: 11 * time.Millisecond, P95: 19 * time.Millisecond, Allocs: 10, Bytes: 1050}},
+	}
+
+	for _, test := range tests {
+		// The slowest run is first to check that the durations are sorted
+		measurements := make([]measurement, test.runs)
+		for i := range measurements {
+			measurements[i] = measurement{
+				duration: time.Duration(test.runs-i) * time.Millisecond,
+				allocs:   uint64(i + 1),
+				bytes:    uint64(100 * (i + 1)),
+			}
+		}
+
+		expected := test.expected
+		expected.Day = "day06"
+		expected.Phase = PHASE_PARSE
+		expected.Runs = test.runs
+
+		if actual := summarise("day06", PHASE_PARSE, measurements); actual != expected {
+			t.Errorf("Expected %+v for %d runs, got %+v", expected, test.runs, actual)
+		}
+	}
+}
@@ -0,0 +1,127 @@
+package aoc
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	YEAR           = 2024
+	SESSION_ENV    = "AOC_SESSION"
+	USER_AGENT     = "github.com/djpiper28/advent-of-code-2024"
+	CACHE_DIR_NAME = "advent-of-code"
+)
+
+// Client talks to the Advent of Code website, the base URL is configurable so
+// that it can be pointed at a local server
+type Client struct {
+	BaseURL  string
+	Session  string
+	CacheDir string
+	HTTP     *http.Client
+	// Overrides the clock used for cooldowns
+	Now func() time.Time
+}
+
+type ClientOptions struct {
+	BaseURL  string `long:"base-url" default:"https://adventofcode.com" description:"Base URL of the Advent of Code website"`
+	Session  string `long:"session" env:"AOC_SESSION" description:"Session cookie used to authenticate with the website"`
+	CacheDir string `long:"cache-dir" description:"Directory that inputs and submissions are stored in, defaults to the user's cache directory"`
+}
+
+func (o *ClientOptions) Client() (*Client, error) {
+	cacheDir := o.CacheDir
+	if cacheDir == "" {
+		var err error
+		cacheDir, err = DefaultCacheDir()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &Client{
+		BaseURL:  strings.TrimSuffix(o.BaseURL, "/"),
+		Session:  o.Session,
+		CacheDir: cacheDir,
+		HTTP:     &http.Client{Timeout: 30 * time.Second},
+	}, nil
+}
+
+func DefaultCacheDir() (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", fmt.Errorf("Cannot find the cache directory: %w", err)
+	}
+
+	return filepath.Join(dir, CACHE_DIR_NAME), nil
+}
+
+// DayDir is where everything stored for a day is kept, i.e:
+// <cache>/2024/day06
+func (c *Client) DayDir(day int) string {
+	return filepath.Join(c.CacheDir, strconv.Itoa(YEAR), fmt.Sprintf("day%02d", day))
+}
+
+func (c *Client) do(req *http.Request) ([]byte, error) {
+	if c.Session == "" {
+		return nil, errors.New("A session token is required, set it with --session or " + SESSION_ENV)
+	}
+
+	req.Header.Set("User-Agent", USER_AGENT)
+	req.AddCookie(&http.Cookie{Name: "session", Value: c.Session})
+
+	resp, err := c.HTTP.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s %s returned %s: %s",
+			req.Method, req.URL, resp.Status, strings.TrimSpace(string(body)))
+	}
+
+	return body, nil
+}
+
+// writePrivateFile atomically writes a file that only the user can read, the
+// session token's owner is the only one allowed to see the inputs
+func writePrivateFile(path string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err = tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+
+	if err = tmp.Chmod(0o600); err != nil {
+		tmp.Close()
+		return err
+	}
+
+	if err = tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), path)
+}
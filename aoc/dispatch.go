@@ -0,0 +1,387 @@
+package aoc
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/charmbracelet/log"
+	"github.com/jessevdk/go-flags"
+)
+
+const DAY_ALL = "all"
+
+// Dispatch is the entrypoint for the multi-day binary, it exposes the days as
+// `list` and `run <day|all>` sub-commands.
+func Dispatch(days []*Day) {
+	var logOpts LogOptions
+	parser := flags.NewParser(nil, flags.Default)
+
+	_, err := parser.AddGroup("Logging Options", "", &logOpts)
+	if err != nil {
+		log.Fatal("Cannot register options", "err", err)
+	}
+
+	// The logger is configured once every option has been parsed, as the
+	// logging options can come after the command
+	parser.CommandHandler = func(command flags.Commander, args []string) error {
+		if err := logOpts.Apply(); err != nil {
+			return err
+		}
+		return command.Execute(args)
+	}
+
+	_, err = parser.AddCommand("list",
+		"List the available days",
+		"Lists every registered day along with its example inputs",
+		&listCommand{days: days})
+	if err != nil {
+		log.Fatal("Cannot register command", "err", err)
+	}
+
+	run, err := parser.AddCommand("run",
+		"Solve a day",
+		"Solves one day, or all of them, and prints a summary of the answers",
+		&runCommand{days: days})
+	if err != nil {
+		log.Fatal("Cannot register command", "err", err)
+	}
+	addDayOptions(run, days)
+
+	_, err = parser.AddCommand("verify",
+		"Check a day against its recorded answers",
+		"Solves one day, or all of them, and fails when an answer no longer matches the recorded answer",
+		&verifyCommand{runCommand: runCommand{days: days}})
+	if err != nil {
+		log.Fatal("Cannot register command", "err", err)
+	}
+
+	_, err = parser.AddCommand("fetch",
+		"Download the input for a day",
+		"Downloads the input for one day, or all of them, into the cache. Cached inputs are never downloaded again",
+		&fetchCommand{days: days})
+	if err != nil {
+		log.Fatal("Cannot register command", "err", err)
+	}
+
+	_, err = parser.AddCommand("submit",
+		"Submit an answer",
+		"Solves one part of a day and submits the answer to the website, the verdict is recorded alongside the cached input",
+		&submitCommand{days: days})
+	if err != nil {
+		log.Fatal("Cannot register command", "err", err)
+	}
+
+	_, err = parser.AddCommand("guesses",
+		"List the submitted answers for a day",
+		"Lists every answer submitted for a day along with its verdict and the bounds that they give",
+		&guessesCommand{days: days})
+	if err != nil {
+		log.Fatal("Cannot register command", "err", err)
+	}
+
+	_, err = parser.AddCommand("new",
+		"Create a new day",
+		"Creates the package for a new day from the template and registers it with the runner and the Makefile, this must be ran from the root of the repo",
+		&newCommand{})
+	if err != nil {
+		log.Fatal("Cannot register command", "err", err)
+	}
+
+	if _, err = parser.Parse(); err != nil {
+		if flags.WroteHelp(err) {
+			os.Exit(0)
+		}
+		log.Fatal("Command failed", "err", err)
+	}
+}
+
+// addDayOptions namespaces each day's options by the day, i.e: --day01.metric.
+// They are only added to run, as verify and submit must always use the
+// puzzle's answer.
+func addDayOptions(command *flags.Command, days []*Day) {
+	for _, day := range days {
+		if day.Options() == nil {
+			continue
+		}
+
+		group, err := command.AddGroup(day.Name+" Options", "", day.Options())
+		if err != nil {
+			log.Fatal("Cannot register options", "day", day.Name, "err", err)
+		}
+		group.Namespace = day.Name
+	}
+}
+
+// selectDays resolves a day name, or "all", into the days to operate on
+func selectDays(days []*Day, name string) ([]*Day, error) {
+	if name == DAY_ALL {
+		return days, nil
+	}
+
+	names := make([]string, 0, len(days))
+	for _, day := range days {
+		if day.Name == name {
+			return []*Day{day}, nil
+		}
+		names = append(names, day.Name)
+	}
+
+	return nil, fmt.Errorf("Cannot find %q, expected one of: %s or %s",
+		name, strings.Join(names, ", "), DAY_ALL)
+}
+
+type listCommand struct {
+	days []*Day
+}
+
+func (c *listCommand) Execute(args []string) error {
+	if len(args) > 0 {
+		return fmt.Errorf("Unexpected positional arguments: %s", strings.Join(args, " "))
+	}
+
+	for _, day := range c.days {
+		fmt.Printf("%s\texamples: %s\n", day.Name, strings.Join(day.ExampleNames(), ", "))
+	}
+
+	return nil
+}
+
+type runCommand struct {
+	Options
+	Args struct {
+		Day string `positional-arg-name:"day" description:"The day to solve, or all"`
+	} `positional-args:"yes" required:"yes"`
+	days []*Day
+}
+
+func (c *runCommand) Execute(args []string) error {
+	if len(args) > 0 {
+		return fmt.Errorf("Unexpected positional arguments: %s", strings.Join(args, " "))
+	}
+
+	days, err := selectDays(c.days, c.Args.Day)
+	if err != nil {
+		return err
+	}
+
+	out, err := NewResultWriter(c.Format, os.Stdout)
+	if err != nil {
+		return err
+	}
+
+	stopProfiling, err := c.Profile.Start()
+	if err != nil {
+		return err
+	}
+
+	ctx, stop := interruptContext()
+	defer stop()
+
+	failed := 0
+	for i, day := range days {
+		if ctx.Err() != nil {
+			log.Warn("Interrupted, skipping the remaining days", "skipped", len(days)-i)
+			failed += len(days) - i
+			break
+		}
+
+		if err = run(ctx, day, &c.Options, out); err != nil {
+			log.Error("Cannot solve puzzle", "day", day.Name, "err", err)
+			failed++
+		}
+	}
+
+	if err = errors.Join(out.Close(), stopProfiling()); err != nil {
+		return err
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d days failed", failed, len(days))
+	}
+
+	return nil
+}
+
+type verifyCommand struct {
+	runCommand
+}
+
+func (c *verifyCommand) Execute(args []string) error {
+	c.Verify = true
+	return c.runCommand.Execute(args)
+}
+
+type fetchCommand struct {
+	ClientOptions
+	Args struct {
+		Day string `positional-arg-name:"day" description:"The day to download the input for, or all"`
+	} `positional-args:"yes" required:"yes"`
+	days []*Day
+}
+
+func (c *fetchCommand) Execute(args []string) error {
+	if len(args) > 0 {
+		return fmt.Errorf("Unexpected positional arguments: %s", strings.Join(args, " "))
+	}
+
+	days, err := selectDays(c.days, c.Args.Day)
+	if err != nil {
+		return err
+	}
+
+	client, err := c.Client()
+	if err != nil {
+		return err
+	}
+
+	for _, day := range days {
+		number, err := day.Number()
+		if err != nil {
+			return err
+		}
+
+		if _, err = client.FetchInput(number); err != nil {
+			return fmt.Errorf("Cannot fetch %s: %w", day.Name, err)
+		}
+	}
+
+	return nil
+}
+
+type submitCommand struct {
+	ClientOptions
+	Input string `short:"i" long:"input" default:"input.txt" description:"Path to the puzzle input or - for stdin, examples cannot be submitted"`
+	Force bool   `long:"force" description:"Submit the answer even when earlier guesses show that it is wrong"`
+	Args  struct {
+		Day  string `positional-arg-name:"day" description:"The day to submit an answer for"`
+		Part int    `positional-arg-name:"part" description:"The part to submit an answer for, 1 or 2"`
+	} `positional-args:"yes" required:"yes"`
+	days []*Day
+}
+
+func (c *submitCommand) Execute(args []string) error {
+	if len(args) > 0 {
+		return fmt.Errorf("Unexpected positional arguments: %s", strings.Join(args, " "))
+	}
+
+	if c.Args.Day == DAY_ALL {
+		return errors.New("Answers can only be submitted for one day at a time")
+	}
+
+	// An example's answer would spend a guess and be recorded as wrong
+	if strings.HasPrefix(c.Input, INPUT_EXAMPLE_PREFIX) {
+		return fmt.Errorf("Cannot submit the answer for %s, examples are not puzzle inputs", c.Input)
+	}
+
+	days, err := selectDays(c.days, c.Args.Day)
+	if err != nil {
+		return err
+	}
+	day := days[0]
+
+	number, err := day.Number()
+	if err != nil {
+		return err
+	}
+
+	client, err := c.Client()
+	if err != nil {
+		return err
+	}
+
+	input, err := day.ReadInput(c.Input)
+	if err != nil {
+		return err
+	}
+
+	ctx, stop := interruptContext()
+	defer stop()
+
+	result, err := day.SolveInput(ctx, input, c.Args.Part)
+	if err != nil {
+		return err
+	}
+
+	submission, err := client.Submit(number, c.Args.Part, result.Record().Answer, c.Force)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%s part %d: %s is %s\n%s\n", day.Name, submission.Part, submission.Answer, submission.Verdict, submission.Message)
+	if submission.Verdict != VerdictCorrect {
+		return fmt.Errorf("The answer was not accepted (%s)", submission.Verdict)
+	}
+
+	return nil
+}
+
+type guessesCommand struct {
+	ClientOptions
+	Args struct {
+		Day string `positional-arg-name:"day" description:"The day to list the guesses for"`
+	} `positional-args:"yes" required:"yes"`
+	days []*Day
+}
+
+func (c *guessesCommand) Execute(args []string) error {
+	if len(args) > 0 {
+		return fmt.Errorf("Unexpected positional arguments: %s", strings.Join(args, " "))
+	}
+
+	days, err := selectDays(c.days, c.Args.Day)
+	if err != nil {
+		return err
+	}
+
+	client, err := c.Client()
+	if err != nil {
+		return err
+	}
+
+	table := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(table, "DAY\tPART\tANSWER\tVERDICT\tTIME")
+
+	for _, day := range days {
+		number, err := day.Number()
+		if err != nil {
+			return err
+		}
+
+		submissions, err := client.LoadSubmissions(number)
+		if err != nil {
+			return err
+		}
+
+		for _, submission := range submissions.Submissions {
+			fmt.Fprintf(table, "%s\t%d\t%s\t%s\t%s\n",
+				day.Name, submission.Part, submission.Answer, submission.Verdict, submission.Time.Format(time.DateTime))
+		}
+
+		for _, part := range []int{1, 2} {
+			if bounds := submissions.Bounds(part); bounds.HasLow || bounds.HasHigh {
+				fmt.Fprintf(table, "%s\t%d\tbounds %s\t\t\n", day.Name, part, bounds)
+			}
+		}
+	}
+
+	return table.Flush()
+}
+
+type newCommand struct {
+	Root string `long:"root" default:"." description:"Path to the root of the repo"`
+	Args struct {
+		Day string `positional-arg-name:"day" description:"The name of the new day, i.e: day07"`
+	} `positional-args:"yes" required:"yes"`
+}
+
+func (c *newCommand) Execute(args []string) error {
+	if len(args) > 0 {
+		return fmt.Errorf("Unexpected positional arguments: %s", strings.Join(args, " "))
+	}
+
+	return Scaffold(c.Root, c.Args.Day)
+}
@@ -0,0 +1,80 @@
+package aoc
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/log"
+)
+
+// Number is the day of the month that the puzzle is for, from the name dayNN
+func (d *Day) Number() (int, error) {
+	number, found := strings.CutPrefix(d.Name, "day")
+	if !found {
+		return 0, fmt.Errorf("Cannot get the day number from %q", d.Name)
+	}
+
+	return strconv.Atoi(number)
+}
+
+func (c *Client) InputPath(day int) string {
+	return filepath.Join(c.DayDir(day), INPUT_DEFAULT)
+}
+
+// CachedInputPath is where `aoc fetch` stores the day's input by default
+func (d *Day) CachedInputPath() (string, error) {
+	number, err := d.Number()
+	if err != nil {
+		return "", err
+	}
+
+	cacheDir, err := DefaultCacheDir()
+	if err != nil {
+		return "", err
+	}
+
+	client := Client{CacheDir: cacheDir}
+	return client.InputPath(number), nil
+}
+
+// FetchInput returns the cached input for the day, downloading it when it has
+// not been cached yet. Inputs never change so they are never refetched.
+func (c *Client) FetchInput(day int) ([]byte, error) {
+	path := c.InputPath(day)
+
+	data, err := os.ReadFile(path)
+	if err == nil {
+		log.Info("Using cached input", "path", path)
+		return data, nil
+	}
+
+	if !errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("Cannot read the cached input: %w", err)
+	}
+
+	url := fmt.Sprintf("%s/%d/day/%d/input", c.BaseURL, YEAR, day)
+	log.Info("Downloading input", "url", url)
+
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err = c.do(req)
+	if err != nil {
+		return nil, fmt.Errorf("Cannot download input: %w", err)
+	}
+
+	if err = writePrivateFile(path, data); err != nil {
+		return nil, fmt.Errorf("Cannot cache input: %w", err)
+	}
+
+	log.Info("Cached input", "path", path)
+	return data, nil
+}
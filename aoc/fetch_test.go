@@ -0,0 +1,92 @@
+package aoc
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+)
+
+func newTestClient(t *testing.T, handler http.HandlerFunc) *Client {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	return &Client{
+		BaseURL:  server.URL,
+		Session:  "test-session",
+		CacheDir: t.TempDir(),
+		HTTP:     server.Client(),
+	}
+}
+
+func TestFetchInput(t *testing.T) {
+	requests := 0
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+
+		if r.URL.Path != "/2024/day/6/input" {
+			t.Errorf("Unexpected path %s", r.URL.Path)
+		}
+
+		cookie, err := r.Cookie("session")
+		if err != nil || cookie.Value != "test-session" {
+			t.Errorf("Expected the session cookie, got %v", cookie)
+		}
+
+		w.Write([]byte("..#\n.^.\n"))
+	})
+
+	for i := 0; i < 2; i++ {
+		data, err := client.FetchInput(6)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if string(data) != "..#\n.^.\n" {
+			t.Errorf("Unexpected input %q", data)
+		}
+	}
+
+	if requests != 1 {
+		t.Errorf("Expected the cached input to be used, made %d requests", requests)
+	}
+
+	info, err := os.Stat(client.InputPath(6))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if info.Mode().Perm() != 0o600 {
+		t.Errorf("Expected the input to only be readable by the user, got %s", info.Mode())
+	}
+}
+
+func TestFetchInputErrors(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "Please don't repeatedly request this endpoint before it unlocks!", http.StatusNotFound)
+	})
+
+	if _, err := client.FetchInput(25); err == nil {
+		t.Fatal("Expected an error")
+	}
+
+	if _, err := os.Stat(client.InputPath(25)); err == nil {
+		t.Error("Failed downloads should not be cached")
+	}
+
+	client.Session = ""
+	if _, err := client.FetchInput(1); err == nil {
+		t.Error("Expected an error without a session")
+	}
+}
+
+func TestDayNumber(t *testing.T) {
+	number, err := (&Day{Name: "day06"}).Number()
+	if err != nil || number != 6 {
+		t.Errorf("Expected 6, got %d %v", number, err)
+	}
+
+	if _, err = (&Day{Name: "template"}).Number(); err == nil {
+		t.Error("Expected an error")
+	}
+}
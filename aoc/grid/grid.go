@@ -0,0 +1,183 @@
+package grid
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/djpiper28/advent-of-code-2024/aoc/tokenizer"
+)
+
+// Directions4 are the steps to the orthogonal neighbours, clockwise from up
+var Directions4 = []Vec2{
+	DirectionUp.Vec(),
+	DirectionRight.Vec(),
+	DirectionDown.Vec(),
+	DirectionLeft.Vec(),
+}
+
+// Directions8 are the steps to all neighbours including diagonals, clockwise
+// from up
+var Directions8 = []Vec2{
+	{X: 0, Y: -1},
+	{X: 1, Y: -1},
+	{X: 1, Y: 0},
+	{X: 1, Y: 1},
+	{X: 0, Y: 1},
+	{X: -1, Y: 1},
+	{X: -1, Y: 0},
+	{X: -1, Y: -1},
+}
+
+// Grid is a rectangle of cells indexed by Vec2, X is the column and Y is the
+// row with 0, 0 in the top left
+type Grid[T any] struct {
+	cells         []T
+	width, height int
+}
+
+func New[T any](width, height int) *Grid[T] {
+	return &Grid[T]{cells: make([]T, width*height), width: width, height: height}
+}
+
+// Parse reads one row per line, each byte is converted to a cell by the
+// mapping function. The trailing new line is optional and CRLF line endings
+// are accepted.
+func Parse[T any](data []byte, cell func(b byte, pos Vec2) (T, error)) (*Grid[T], error) {
+	ret := &Grid[T]{cells: make([]T, 0, len(data))}
+	t := tokenizer.New(bytes.NewReader(data))
+
+	for y := 0; !t.EOF(); y++ {
+		line, err := t.Line()
+		if err != nil {
+			return nil, err
+		}
+
+		if y == 0 {
+			ret.width = len(line)
+		} else if len(line) != ret.width {
+			return nil, &tokenizer.Diagnostic{
+				Line:   y + 1,
+				Column: min(len(line), ret.width) + 1,
+				Source: string(line),
+				Err:    fmt.Errorf("The grid is not a rectangle, the row has length %d, expected %d", len(line), ret.width),
+			}
+		}
+
+		for x, b := range line {
+			value, err := cell(b, Vec2{X: x, Y: y})
+			if err != nil {
+				return nil, &tokenizer.Diagnostic{Line: y + 1, Column: x + 1, Source: string(line), Err: err}
+			}
+			ret.cells = append(ret.cells, value)
+		}
+
+		ret.height++
+	}
+
+	return ret, nil
+}
+
+// ParseBytes reads a grid where each cell is the byte from the input
+func ParseBytes(data []byte) (*Grid[byte], error) {
+	return Parse(data, func(b byte, _ Vec2) (byte, error) {
+		return b, nil
+	})
+}
+
+func (g *Grid[T]) Width() int {
+	return g.width
+}
+
+func (g *Grid[T]) Height() int {
+	return g.height
+}
+
+func (g *Grid[T]) InBounds(p Vec2) bool {
+	return p.X >= 0 && p.X < g.width && p.Y >= 0 && p.Y < g.height
+}
+
+// At returns the cell at p, it panics when p is out of bounds
+func (g *Grid[T]) At(p Vec2) T {
+	if !g.InBounds(p) {
+		panic(fmt.Sprintf("%v is out of the bounds of a %dx%d grid", p, g.width, g.height))
+	}
+	return g.cells[p.Y*g.width+p.X]
+}
+
+// Get returns the cell at p and whether p is in bounds
+func (g *Grid[T]) Get(p Vec2) (T, bool) {
+	if !g.InBounds(p) {
+		var zero T
+		return zero, false
+	}
+	return g.cells[p.Y*g.width+p.X], true
+}
+
+// Set changes the cell at p, it panics when p is out of bounds
+func (g *Grid[T]) Set(p Vec2, value T) {
+	if !g.InBounds(p) {
+		panic(fmt.Sprintf("%v is out of the bounds of a %dx%d grid", p, g.width, g.height))
+	}
+	g.cells[p.Y*g.width+p.X] = value
+}
+
+func (g *Grid[T]) neighbours(p Vec2, directions []Vec2) []Vec2 {
+	ret := make([]Vec2, 0, len(directions))
+	for _, direction := range directions {
+		neighbour := p.Add(direction)
+		if g.InBounds(neighbour) {
+			ret = append(ret, neighbour)
+		}
+	}
+
+	return ret
+}
+
+// Neighbours4 are the in bounds orthogonal neighbours of p
+func (g *Grid[T]) Neighbours4(p Vec2) []Vec2 {
+	return g.neighbours(p, Directions4)
+}
+
+// Neighbours8 are the in bounds neighbours of p including diagonals
+func (g *Grid[T]) Neighbours8(p Vec2) []Vec2 {
+	return g.neighbours(p, Directions8)
+}
+
+// Each calls f for every cell, row by row
+func (g *Grid[T]) Each(f func(p Vec2, value T)) {
+	for i, value := range g.cells {
+		f(Vec2{X: i % g.width, Y: i / g.width}, value)
+	}
+}
+
+// Find returns the position of the first cell, row by row, that matches
+func (g *Grid[T]) Find(match func(T) bool) (Vec2, error) {
+	for i, value := range g.cells {
+		if match(value) {
+			return Vec2{X: i % g.width, Y: i / g.width}, nil
+		}
+	}
+
+	return Vec2{}, errors.New("Cannot find a matching cell")
+}
+
+func (g *Grid[T]) Clone() *Grid[T] {
+	cells := make([]T, len(g.cells))
+	copy(cells, g.cells)
+	return &Grid[T]{cells: cells, width: g.width, height: g.height}
+}
+
+// Format pretty-prints the grid with one line per row
+func (g *Grid[T]) Format(cell func(T) string) string {
+	var builder strings.Builder
+	for y := 0; y < g.height; y++ {
+		for x := 0; x < g.width; x++ {
+			builder.WriteString(cell(g.cells[y*g.width+x]))
+		}
+		builder.WriteByte('\n')
+	}
+
+	return builder.String()
+}
@@ -0,0 +1,117 @@
+package grid
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/djpiper28/advent-of-code-2024/aoc/tokenizer"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name          string
+		input         string
+		width, height int
+		expectedError bool
+	}{
+		{name: "trailing new line", input: "abc\ndef\n", width: 3, height: 2},
+		{name: "no trailing new line", input: "abc\ndef", width: 3, height: 2},
+		{name: "crlf", input: "abc\r\ndef\r\n", width: 3, height: 2},
+		{name: "empty", input: "", width: 0, height: 0},
+		{name: "not a rectangle", input: "abc\nde\n", expectedError: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			g, err := ParseBytes([]byte(test.input))
+			if test.expectedError {
+				if err == nil {
+					t.Fatal("Expected an error")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if g.Width() != test.width || g.Height() != test.height {
+				t.Errorf("Expected %dx%d, got %dx%d", test.width, test.height, g.Width(), g.Height())
+			}
+		})
+	}
+}
+
+func TestParseCellError(t *testing.T) {
+	_, err := Parse([]byte("ab\ncd\n"), func(b byte, pos Vec2) (int, error) {
+		if b == 'c' {
+			return 0, errors.New("bad cell")
+		}
+		return int(b), nil
+	})
+
+	var diagnostic *tokenizer.Diagnostic
+	if !errors.As(err, &diagnostic) {
+		t.Fatalf("Expected a diagnostic, got %v", err)
+	}
+
+	if diagnostic.Line != 2 || diagnostic.Column != 1 || diagnostic.Source != "cd" {
+		t.Errorf("Expected the error at 2:1 in cd, got %d:%d in %q", diagnostic.Line, diagnostic.Column, diagnostic.Source)
+	}
+}
+
+func TestAccess(t *testing.T) {
+	g, err := ParseBytes([]byte("abc\ndef\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if g.At(Vec2{X: 2, Y: 1}) != 'f' {
+		t.Errorf("Expected f, got %c", g.At(Vec2{X: 2, Y: 1}))
+	}
+
+	if _, ok := g.Get(Vec2{X: 3, Y: 0}); ok {
+		t.Error("Expected 3, 0 to be out of bounds")
+	}
+
+	clone := g.Clone()
+	clone.Set(Vec2{X: 0, Y: 0}, 'z')
+	if g.At(Vec2{X: 0, Y: 0}) != 'a' {
+		t.Error("Modifying the clone changed the original")
+	}
+
+	pos, err := g.Find(func(b byte) bool { return b == 'e' })
+	if err != nil || pos != (Vec2{X: 1, Y: 1}) {
+		t.Errorf("Expected to find e at 1, 1, got %v %v", pos, err)
+	}
+
+	if _, err = g.Find(func(b byte) bool { return b == 'z' }); err == nil {
+		t.Error("Expected not to find z")
+	}
+
+	formatted := clone.Format(func(b byte) string { return string(b) })
+	if formatted != "zbc\ndef\n" {
+		t.Errorf("Unexpected format %q", formatted)
+	}
+}
+
+func TestNeighbours(t *testing.T) {
+	g := New[int](3, 3)
+
+	if actual := g.Neighbours4(Vec2{X: 0, Y: 0}); !slices.Equal(actual, []Vec2{{X: 1, Y: 0}, {X: 0, Y: 1}}) {
+		t.Errorf("Unexpected corner neighbours %v", actual)
+	}
+
+	if actual := g.Neighbours4(Vec2{X: 1, Y: 1}); len(actual) != 4 {
+		t.Errorf("Expected 4 neighbours, got %v", actual)
+	}
+
+	if actual := g.Neighbours8(Vec2{X: 1, Y: 1}); len(actual) != 8 {
+		t.Errorf("Expected 8 neighbours, got %v", actual)
+	}
+
+	if actual := g.Neighbours8(Vec2{X: 2, Y: 2}); len(actual) != 3 {
+		t.Errorf("Expected 3 neighbours, got %v", actual)
+	}
+}
@@ -0,0 +1,52 @@
+// Package grid contains the types used by the 2D puzzles
+package grid
+
+type Vec2 struct {
+	X, Y int
+}
+
+func (v Vec2) Add(other Vec2) Vec2 {
+	return Vec2{X: v.X + other.X, Y: v.Y + other.Y}
+}
+
+// Direction is a compass direction, it starts at up and goes clockwise
+type Direction int
+
+const (
+	DirectionUp Direction = iota
+	DirectionRight
+	DirectionDown
+	DirectionLeft
+)
+
+var directionVecs = [...]Vec2{
+	DirectionUp:    {X: 0, Y: -1},
+	DirectionRight: {X: 1, Y: 0},
+	DirectionDown:  {X: 0, Y: 1},
+	DirectionLeft:  {X: -1, Y: 0},
+}
+
+// RotateRight turns 90 degrees clockwise
+func (d Direction) RotateRight() Direction {
+	return (d + 1) % 4
+}
+
+// Vec is the step taken when moving in the direction, y grows downwards
+func (d Direction) Vec() Vec2 {
+	return directionVecs[d]
+}
+
+func (d Direction) String() string {
+	switch d {
+	case DirectionUp:
+		return "up"
+	case DirectionRight:
+		return "right"
+	case DirectionDown:
+		return "down"
+	case DirectionLeft:
+		return "left"
+	default:
+		return "unknown"
+	}
+}
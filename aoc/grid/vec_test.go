@@ -0,0 +1,36 @@
+package grid
+
+import (
+	"testing"
+)
+
+func TestDirection(t *testing.T) {
+	tests := []struct {
+		direction Direction
+		vec       Vec2
+		rotated   Direction
+	}{
+		{direction: DirectionUp, vec: Vec2{X: 0, Y: -1}, rotated: DirectionRight},
+		{direction: DirectionRight, vec: Vec2{X: 1, Y: 0}, rotated: DirectionDown},
+		{direction: DirectionDown, vec: Vec2{X: 0, Y: 1}, rotated: DirectionLeft},
+		{direction: DirectionLeft, vec: Vec2{X: -1, Y: 0}, rotated: DirectionUp},
+	}
+
+	for _, test := range tests {
+		t.Run(test.direction.String(), func(t *testing.T) {
+			if actual := test.direction.Vec(); actual != test.vec {
+				t.Errorf("Expected %v, got %v", test.vec, actual)
+			}
+
+			if actual := test.direction.RotateRight(); actual != test.rotated {
+				t.Errorf("Expected to rotate to %s, got %s", test.rotated, actual)
+			}
+		})
+	}
+}
+
+func TestVec2Add(t *testing.T) {
+	if actual := (Vec2{X: 1, Y: 2}).Add(Vec2{X: -3, Y: 4}); actual != (Vec2{X: -2, Y: 6}) {
+		t.Errorf("Unexpected sum %v", actual)
+	}
+}
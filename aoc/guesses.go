@@ -0,0 +1,116 @@
+package aoc
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// Bounds is the range that the answer to a part must be in, going by the
+// "too high" and "too low" verdicts of earlier guesses
+type Bounds struct {
+	// Largest guess that was too low, the answer is above this
+	Low    int64
+	HasLow bool
+	// Smallest guess that was too high, the answer is below this
+	High    int64
+	HasHigh bool
+}
+
+func (b Bounds) Contains(answer int64) bool {
+	return (!b.HasLow || answer > b.Low) && (!b.HasHigh || answer < b.High)
+}
+
+func (b Bounds) String() string {
+	low, high := "-inf", "+inf"
+	if b.HasLow {
+		low = strconv.FormatInt(b.Low, 10)
+	}
+
+	if b.HasHigh {
+		high = strconv.FormatInt(b.High, 10)
+	}
+
+	return fmt.Sprintf("(%s, %s)", low, high)
+}
+
+func (l *SubmissionLog) Bounds(part int) Bounds {
+	var bounds Bounds
+	for _, submission := range l.Submissions {
+		if submission.Part != part {
+			continue
+		}
+
+		guess, err := strconv.ParseInt(submission.Answer, 10, 64)
+		if err != nil {
+			continue
+		}
+
+		switch submission.Verdict {
+		case VerdictTooLow:
+			if !bounds.HasLow || guess > bounds.Low {
+				bounds.Low, bounds.HasLow = guess, true
+			}
+		case VerdictTooHigh:
+			if !bounds.HasHigh || guess < bounds.High {
+				bounds.High, bounds.HasHigh = guess, true
+			}
+		}
+	}
+
+	return bounds
+}
+
+// GuessError explains why an answer is known to be wrong without submitting
+// it
+type GuessError struct {
+	Part   int
+	Answer string
+	Reason string
+}
+
+func (g *GuessError) Error() string {
+	return fmt.Sprintf("Part %d answer %s %s", g.Part, g.Answer, g.Reason)
+}
+
+// Check compares the answer against the earlier guesses, returning a
+// GuessError when it has already been rejected, is outside of the known
+// bounds or the part has already been solved
+func (l *SubmissionLog) Check(part int, answer string) error {
+	for _, submission := range l.Submissions {
+		if submission.Part != part {
+			continue
+		}
+
+		switch submission.Verdict {
+		case VerdictCorrect:
+			reason := "has already been accepted"
+			if submission.Answer != answer {
+				reason = fmt.Sprintf("is not the accepted answer %s", submission.Answer)
+			}
+			return &GuessError{Part: part, Answer: answer, Reason: reason}
+		case VerdictTooHigh, VerdictTooLow, VerdictWrong:
+			if submission.Answer == answer {
+				return &GuessError{
+					Part:   part,
+					Answer: answer,
+					Reason: fmt.Sprintf("was already rejected as %s", submission.Verdict),
+				}
+			}
+		}
+	}
+
+	guess, err := strconv.ParseInt(answer, 10, 64)
+	if err != nil {
+		return nil
+	}
+
+	if bounds := l.Bounds(part); !bounds.Contains(guess) {
+		return &GuessError{
+			Part:   part,
+			Answer: answer,
+			Reason: fmt.Sprintf("is outside of the known bounds %s", bounds),
+		}
+	}
+
+	return nil
+}
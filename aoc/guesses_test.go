@@ -0,0 +1,52 @@
+package aoc
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestCheckGuess(t *testing.T) {
+	log := SubmissionLog{Submissions: []Submission{
+		{Part: 1, Answer: "100", Verdict: VerdictTooLow},
+		{Part: 1, Answer: "150", Verdict: VerdictTooLow},
+		{Part: 1, Answer: "500", Verdict: VerdictTooHigh},
+		{Part: 1, Answer: "300", Verdict: VerdictWrong},
+		{Part: 2, Answer: "42", Verdict: VerdictCorrect},
+	}}
+
+	tests := []struct {
+		name   string
+		part   int
+		answer string
+		valid  bool
+	}{
+		{name: "inside bounds", part: 1, answer: "200", valid: true},
+		{name: "equal to too low", part: 1, answer: "150", valid: false},
+		{name: "below too low", part: 1, answer: "120", valid: false},
+		{name: "equal to too high", part: 1, answer: "500", valid: false},
+		{name: "above too high", part: 1, answer: "900", valid: false},
+		{name: "already rejected", part: 1, answer: "300", valid: false},
+		{name: "not a number", part: 1, answer: "abc", valid: true},
+		{name: "already solved", part: 2, answer: "42", valid: false},
+		{name: "differs from solved", part: 2, answer: "43", valid: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := log.Check(test.part, test.answer)
+			if test.valid && err != nil {
+				t.Errorf("Expected the guess to be allowed, got %v", err)
+			}
+
+			var guessErr *GuessError
+			if !test.valid && !errors.As(err, &guessErr) {
+				t.Errorf("Expected a GuessError, got %v", err)
+			}
+		})
+	}
+
+	bounds := log.Bounds(1)
+	if !bounds.HasLow || bounds.Low != 150 || !bounds.HasHigh || bounds.High != 500 {
+		t.Errorf("Unexpected bounds %s", bounds)
+	}
+}
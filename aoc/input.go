@@ -0,0 +1,145 @@
+package aoc
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/djpiper28/advent-of-code-2024/aoc/tokenizer"
+)
+
+const (
+	INPUT_DEFAULT        = "input.txt"
+	INPUT_STDIN          = "-"
+	INPUT_STDIN_NAME     = "<stdin>"
+	INPUT_EXAMPLE_PREFIX = "example:"
+	EXAMPLES_DIR         = "examples"
+)
+
+type Input struct {
+	// Where the input was read from, i.e: a path, "-" or "example:name"
+	Name string
+	Data []byte
+}
+
+func (i Input) Sha256() string {
+	hash := sha256.Sum256(i.Data)
+	return hex.EncodeToString(hash[:])
+}
+
+// annotate fills in the file name of a parse diagnostic
+func (i Input) annotate(err error) error {
+	var diagnostic *tokenizer.Diagnostic
+	if errors.As(err, &diagnostic) && diagnostic.File == "" {
+		diagnostic.File = i.Name
+		if i.Name == INPUT_STDIN {
+			diagnostic.File = INPUT_STDIN_NAME
+		}
+	}
+
+	return err
+}
+
+// ReadInput loads the puzzle input from source, which can be a path, "-" for
+// stdin or "example:name" for one of the day's embedded example fixtures.
+// When the source is the default input.txt and it does not exist in the
+// working directory then the day's own directory is checked, so that days can
+// be ran from the root of the repo, and then the input cached by `aoc fetch`.
+func (d *Day) ReadInput(source string) (Input, error) {
+	switch {
+	case source == INPUT_STDIN:
+		data, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			return Input{}, fmt.Errorf("Cannot read stdin: %w", err)
+		}
+		return Input{Name: source, Data: data}, nil
+	case strings.HasPrefix(source, INPUT_EXAMPLE_PREFIX):
+		return d.readExample(strings.TrimPrefix(source, INPUT_EXAMPLE_PREFIX))
+	case source == "" || source == INPUT_DEFAULT:
+		return d.readDefaultInput()
+	default:
+		return readInputFile(source)
+	}
+}
+
+func (d *Day) readDefaultInput() (Input, error) {
+	path := d.locate(INPUT_DEFAULT)
+	if _, err := os.Stat(path); errors.Is(err, fs.ErrNotExist) {
+		cached, cacheErr := d.CachedInputPath()
+		if _, err = os.Stat(cached); cacheErr == nil && err == nil {
+			path = cached
+		}
+	}
+
+	return readInputFile(path)
+}
+
+// locate resolves a file relative to the working directory, falling back to
+// the day's own directory when ran from the root of the repo
+func (d *Day) locate(path string) string {
+	if filepath.IsAbs(path) {
+		return path
+	}
+
+	if _, err := os.Stat(path); !errors.Is(err, fs.ErrNotExist) {
+		return path
+	}
+
+	if info, err := os.Stat(d.Name); err == nil && info.IsDir() {
+		return filepath.Join(d.Name, path)
+	}
+
+	return path
+}
+
+func readInputFile(path string) (Input, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Input{}, fmt.Errorf("Cannot read the data from the file: %w", err)
+	}
+
+	return Input{Name: path, Data: data}, nil
+}
+
+func (d *Day) readExample(name string) (Input, error) {
+	if d.Examples == nil {
+		return Input{}, fmt.Errorf("%s has no example inputs", d.Name)
+	}
+
+	data, err := fs.ReadFile(d.Examples, EXAMPLES_DIR+"/"+name+".txt")
+	if err != nil {
+		return Input{}, fmt.Errorf("Cannot read example %q (available: %s): %w",
+			name, strings.Join(d.ExampleNames(), ", "), err)
+	}
+
+	return Input{Name: INPUT_EXAMPLE_PREFIX + name, Data: data}, nil
+}
+
+// ExampleNames lists the names of the embedded examples that can be used with
+// the "example:" input prefix
+func (d *Day) ExampleNames() []string {
+	names := make([]string, 0)
+	if d.Examples == nil {
+		return names
+	}
+
+	entries, err := fs.ReadDir(d.Examples, EXAMPLES_DIR)
+	if err != nil {
+		return names
+	}
+
+	for _, entry := range entries {
+		name, isText := strings.CutSuffix(entry.Name(), ".txt")
+		if !entry.IsDir() && isText {
+			names = append(names, name)
+		}
+	}
+
+	return names
+}
@@ -0,0 +1,189 @@
+package aoc
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+// chdir moves into dir for the rest of the test, t.Chdir needs go 1.24
+func chdir(t *testing.T, dir string) {
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err = os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.Chdir(wd) })
+}
+
+func writeFile(t *testing.T, path, data string) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func newTestDay() *Day {
+	day := &Day{Name: "day06"}
+	day.Examples = fstest.MapFS{
+		"examples/example.txt": {Data: []byte("example\n")},
+		"examples/small.txt":   {Data: []byte("small\n")},
+		"examples/notes.md":    {Data: []byte("not an example\n")},
+	}
+	return day
+}
+
+func TestReadInputStdin(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "stdin")
+	writeFile(t, path, "from stdin\n")
+
+	stdin, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer stdin.Close()
+
+	original := os.Stdin
+	os.Stdin = stdin
+	t.Cleanup(func() { os.Stdin = original })
+
+	input, err := newTestDay().ReadInput(INPUT_STDIN)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if input.Name != INPUT_STDIN || string(input.Data) != "from stdin\n" {
+		t.Errorf("Unexpected input %q %q", input.Name, input.Data)
+	}
+}
+
+func TestReadInputExample(t *testing.T) {
+	day := newTestDay()
+
+	input, err := day.ReadInput(INPUT_EXAMPLE_PREFIX + "small")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if input.Name != "example:small" || string(input.Data) != "small\n" {
+		t.Errorf("Unexpected input %q %q", input.Name, input.Data)
+	}
+
+	_, err = day.ReadInput(INPUT_EXAMPLE_PREFIX + "missing")
+	if err == nil {
+		t.Fatal("Expected an error for an unknown example")
+	}
+
+	if !strings.Contains(err.Error(), "example, small") {
+		t.Errorf("Expected the error to list the examples, got %s", err)
+	}
+
+	if _, err = (&Day{Name: "day06"}).ReadInput(INPUT_EXAMPLE_PREFIX + "example"); err == nil {
+		t.Error("Expected an error for a day without examples")
+	}
+}
+
+func TestExampleNames(t *testing.T) {
+	names := newTestDay().ExampleNames()
+	if strings.Join(names, ",") != "example,small" {
+		t.Errorf("Expected example,small, got %v", names)
+	}
+}
+
+func TestReadInputPath(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "puzzle.txt")
+	writeFile(t, path, "puzzle\n")
+
+	input, err := newTestDay().ReadInput(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if input.Name != path || string(input.Data) != "puzzle\n" {
+		t.Errorf("Unexpected input %q %q", input.Name, input.Data)
+	}
+
+	if _, err = newTestDay().ReadInput(filepath.Join(t.TempDir(), "missing.txt")); err == nil {
+		t.Error("Expected an error for a missing file")
+	}
+}
+
+func TestReadDefaultInput(t *testing.T) {
+	tests := []struct {
+		name     string
+		files    map[string]string
+		cached   string
+		expected string
+		path     string
+	}{
+		{
+			name:     "working directory",
+			files:    map[string]string{"input.txt": "working\n", "day06/input.txt": "day\n"},
+			cached:   "cached\n",
+			expected: "working\n",
+			path:     "input.txt",
+		},
+		{
+			name:     "day directory",
+			files:    map[string]string{"day06/input.txt": "day\n"},
+			cached:   "cached\n",
+			expected: "day\n",
+			path:     filepath.Join("day06", "input.txt"),
+		},
+		{
+			name:     "cached input",
+			files:    map[string]string{},
+			cached:   "cached\n",
+			expected: "cached\n",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Setenv("XDG_CACHE_HOME", t.TempDir())
+			chdir(t, t.TempDir())
+
+			for path, data := range test.files {
+				writeFile(t, path, data)
+			}
+
+			day := newTestDay()
+			cached, err := day.CachedInputPath()
+			if err != nil {
+				t.Fatal(err)
+			}
+			writeFile(t, cached, test.cached)
+
+			input, err := day.ReadInput(INPUT_DEFAULT)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			path := test.path
+			if path == "" {
+				path = cached
+			}
+
+			if input.Name != path || string(input.Data) != test.expected {
+				t.Errorf("Expected %q from %s, got %q from %s", test.expected, path, input.Data, input.Name)
+			}
+		})
+	}
+}
+
+func TestReadDefaultInputMissing(t *testing.T) {
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+	chdir(t, t.TempDir())
+
+	if _, err := newTestDay().ReadInput(INPUT_DEFAULT); err == nil {
+		t.Fatal("Expected an error when there is no input")
+	}
+}
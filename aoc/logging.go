@@ -0,0 +1,55 @@
+package aoc
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/charmbracelet/log"
+)
+
+type LogOptions struct {
+	Level  string `long:"log-level" choice:"debug" choice:"info" choice:"warn" choice:"error" choice:"fatal" default:"info" description:"The least severe log messages to show"`
+	Format string `long:"log-format" choice:"text" choice:"json" choice:"logfmt" default:"text" description:"Format of the log messages"`
+	File   string `long:"log-file" value-name:"FILE" description:"Append the log messages to a file instead of writing them to stderr"`
+}
+
+var LOG_FORMATTERS = map[string]log.Formatter{
+	"text":   log.TextFormatter,
+	"json":   log.JSONFormatter,
+	"logfmt": log.LogfmtFormatter,
+}
+
+// Apply configures the default logger, which every solver logs through. Logs
+// go to stderr unless a file is given so that stdout only has the results. The
+// log file is left open until the process exits as log.Fatal can be the last
+// thing that is logged.
+func (o *LogOptions) Apply() error {
+	level, err := log.ParseLevel(o.Level)
+	if err != nil {
+		return fmt.Errorf("Cannot parse the log level: %w", err)
+	}
+
+	formatter, found := LOG_FORMATTERS[o.Format]
+	if !found {
+		return fmt.Errorf("Unknown log format %q, expected text, json or logfmt", o.Format)
+	}
+
+	output := os.Stderr
+	if o.File != "" {
+		if err = os.MkdirAll(filepath.Dir(o.File), 0o755); err != nil {
+			return fmt.Errorf("Cannot create the directory for the log file: %w", err)
+		}
+
+		output, err = os.OpenFile(o.File, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+		if err != nil {
+			return fmt.Errorf("Cannot open the log file: %w", err)
+		}
+	}
+
+	log.SetOutput(output)
+	log.SetFormatter(formatter)
+	log.SetLevel(level)
+
+	return nil
+}
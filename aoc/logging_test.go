@@ -0,0 +1,72 @@
+package aoc
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/log"
+)
+
+func resetLogging(t *testing.T) {
+	level := log.GetLevel()
+	t.Cleanup(func() {
+		log.SetOutput(os.Stderr)
+		log.SetFormatter(log.TextFormatter)
+		log.SetLevel(level)
+	})
+}
+
+func TestLogOptions(t *testing.T) {
+	resetLogging(t)
+	path := filepath.Join(t.TempDir(), "logs", "aoc.log")
+
+	opts := LogOptions{Level: "warn", Format: "json", File: path}
+	if err := opts.Apply(); err != nil {
+		t.Fatal(err)
+	}
+
+	log.Info("Hidden")
+	log.Warn("Shown", "day", "day06")
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	if len(lines) != 1 {
+		t.Fatalf("Expected one log line, got %q", data)
+	}
+
+	var line map[string]any
+	if err = json.Unmarshal([]byte(lines[0]), &line); err != nil {
+		t.Fatal(err)
+	}
+
+	if line["msg"] != "Shown" || line["day"] != "day06" {
+		t.Errorf("Unexpected log line %v", line)
+	}
+}
+
+func TestLogOptionsErrors(t *testing.T) {
+	resetLogging(t)
+
+	tests := []struct {
+		name string
+		opts LogOptions
+	}{
+		{name: "level", opts: LogOptions{Level: "loud", Format: "text"}},
+		{name: "format", opts: LogOptions{Level: "info", Format: "xml"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if err := test.opts.Apply(); err == nil {
+				t.Error("Expected an error")
+			}
+		})
+	}
+}
@@ -0,0 +1,25 @@
+// Package mathx contains the small numeric helpers that the days share
+package mathx
+
+import (
+	"golang.org/x/exp/constraints"
+)
+
+func Abs[T constraints.Signed](x T) T {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
+
+// Sign is -1, 0 or 1 depending on whether x is negative, zero or positive
+func Sign[T constraints.Signed](x T) T {
+	switch {
+	case x < 0:
+		return -1
+	case x > 0:
+		return 1
+	default:
+		return 0
+	}
+}
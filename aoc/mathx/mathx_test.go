@@ -0,0 +1,41 @@
+package mathx
+
+import (
+	"testing"
+)
+
+func TestAbs(t *testing.T) {
+	tests := []struct {
+		input, expected int
+	}{
+		{input: 0, expected: 0},
+		{input: 5, expected: 5},
+		{input: -5, expected: 5},
+	}
+
+	for _, test := range tests {
+		if actual := Abs(test.input); actual != test.expected {
+			t.Errorf("Abs(%d) expected %d, got %d", test.input, test.expected, actual)
+		}
+	}
+
+	if actual := Abs(int8(-3)); actual != 3 {
+		t.Errorf("Abs(int8(-3)) expected 3, got %d", actual)
+	}
+}
+
+func TestSign(t *testing.T) {
+	tests := []struct {
+		input, expected int
+	}{
+		{input: 0, expected: 0},
+		{input: 5, expected: 1},
+		{input: -5, expected: -1},
+	}
+
+	for _, test := range tests {
+		if actual := Sign(test.input); actual != test.expected {
+			t.Errorf("Sign(%d) expected %d, got %d", test.input, test.expected, actual)
+		}
+	}
+}
@@ -0,0 +1,191 @@
+package aoc
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+)
+
+const (
+	FORMAT_TEXT   = "text"
+	FORMAT_JSON   = "json"
+	FORMAT_NDJSON = "ndjson"
+)
+
+// Table is implemented by answers that are too big to show on one line, i.e: a
+// matrix. They are shown in full after the summary of the answers.
+type Table interface {
+	Header() []string
+	Rows() [][]string
+}
+
+// answerString is the one line form of an answer. Tables are shown in full
+// separately, so unless they describe themselves they are shown by their size.
+func answerString(answer any) string {
+	if _, ok := answer.(fmt.Stringer); !ok {
+		if table, ok := answer.(Table); ok {
+			return fmt.Sprintf("%dx%d table", len(table.Rows()), len(table.Header()))
+		}
+	}
+
+	return fmt.Sprint(answer)
+}
+
+// Record is the stable, machine readable form of a Result
+type Record struct {
+	Day         string `json:"day"`
+	Part        int    `json:"part"`
+	Answer      string `json:"answer"`
+	ParseTimeNs int64  `json:"parse_time_ns"`
+	SolveTimeNs int64  `json:"solve_time_ns"`
+	Input       string `json:"input"`
+	InputSha256 string `json:"input_sha256"`
+	// The header then the rows of answers that are a Table
+	Table [][]string `json:"table,omitempty"`
+}
+
+func (r Result) Record() Record {
+	record := Record{
+		Day:         r.Day,
+		Part:        r.Part,
+		Answer:      answerString(r.Answer),
+		ParseTimeNs: r.ParseTime.Nanoseconds(),
+		SolveTimeNs: r.SolveTime.Nanoseconds(),
+		Input:       r.Input,
+		InputSha256: r.InputSha256,
+	}
+
+	if table, ok := r.Answer.(Table); ok {
+		record.Table = append([][]string{table.Header()}, table.Rows()...)
+	}
+
+	return record
+}
+
+type ResultWriter interface {
+	Write(result Result) error
+	WriteBench(stats BenchStats) error
+	// Flushes any buffered results, this must be called once all results are
+	// written
+	Close() error
+}
+
+func NewResultWriter(format string, w io.Writer) (ResultWriter, error) {
+	switch format {
+	case FORMAT_TEXT:
+		return &tableWriter{w: w, results: make([]Result, 0), bench: make([]BenchStats, 0)}, nil
+	case FORMAT_JSON:
+		return &jsonWriter{w: w, records: make([]Record, 0), bench: make([]BenchStats, 0)}, nil
+	case FORMAT_NDJSON:
+		return &ndjsonWriter{encoder: json.NewEncoder(w)}, nil
+	default:
+		return nil, fmt.Errorf("Unknown output format %q", format)
+	}
+}
+
+// tableWriter collects the results into a summary table of answers and
+// timings
+type tableWriter struct {
+	w       io.Writer
+	results []Result
+	bench   []BenchStats
+}
+
+func (t *tableWriter) Write(result Result) error {
+	t.results = append(t.results, result)
+	return nil
+}
+
+func (t *tableWriter) WriteBench(stats BenchStats) error {
+	t.bench = append(t.bench, stats)
+	return nil
+}
+
+func (t *tableWriter) Close() error {
+	table := tabwriter.NewWriter(t.w, 0, 0, 2, ' ', 0)
+
+	if len(t.results) > 0 {
+		fmt.Fprintln(table, "DAY\tPART\tANSWER\tPARSE\tSOLVE")
+		for _, result := range t.results {
+			fmt.Fprintf(table, "%s\t%d\t%s\t%s\t%s\n",
+				result.Day, result.Part, answerString(result.Answer), result.ParseTime, result.SolveTime)
+		}
+	}
+
+	if len(t.bench) > 0 {
+		fmt.Fprintln(table, "DAY\tPHASE\tRUNS\tMIN\tMEDIAN\tP95\tALLOCS/RUN\tBYTES/RUN")
+		for _, stats := range t.bench {
+			fmt.Fprintf(table, "%s\t%s\t%d\t%s\t%s\t%s\t%d\t%d\n",
+				stats.Day, stats.Phase, stats.Runs, stats.Min, stats.Median, stats.P95, stats.Allocs, stats.Bytes)
+		}
+	}
+
+	if err := table.Flush(); err != nil {
+		return err
+	}
+
+	for _, result := range t.results {
+		answer, ok := result.Answer.(Table)
+		if !ok {
+			continue
+		}
+
+		fmt.Fprintf(t.w, "\n%s part %d\n", result.Day, result.Part)
+		table = tabwriter.NewWriter(t.w, 0, 0, 2, ' ', tabwriter.AlignRight)
+		for _, row := range append([][]string{answer.Header()}, answer.Rows()...) {
+			fmt.Fprintln(table, strings.Join(row, "\t")+"\t")
+		}
+
+		if err := table.Flush(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// jsonWriter writes a single array of records, or of benchmark stats when
+// benchmarking
+type jsonWriter struct {
+	w       io.Writer
+	records []Record
+	bench   []BenchStats
+}
+
+func (j *jsonWriter) Write(result Result) error {
+	j.records = append(j.records, result.Record())
+	return nil
+}
+
+func (j *jsonWriter) WriteBench(stats BenchStats) error {
+	j.bench = append(j.bench, stats)
+	return nil
+}
+
+func (j *jsonWriter) Close() error {
+	encoder := json.NewEncoder(j.w)
+	encoder.SetIndent("", "  ")
+
+	if len(j.bench) > 0 {
+		return encoder.Encode(j.bench)
+	}
+	return encoder.Encode(j.records)
+}
+
+type ndjsonWriter struct {
+	encoder *json.Encoder
+}
+
+func (n *ndjsonWriter) Write(result Result) error {
+	return n.encoder.Encode(result.Record())
+}
+
+func (n *ndjsonWriter) WriteBench(stats BenchStats) error {
+	return n.encoder.Encode(stats)
+}
+
+func (n *ndjsonWriter) Close() error {
+	return nil
+}
@@ -0,0 +1,199 @@
+package aoc
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+	"time"
+)
+
+// testTable is shaped like a real matrix answer and does not describe itself
+type testTable [][]int
+
+func (t testTable) Header() []string {
+	return []string{"", "a", "b"}
+}
+
+func (t testTable) Rows() [][]string {
+	return [][]string{{"a", fmt.Sprint(t[0][0]), fmt.Sprint(t[0][1])}, {"b", fmt.Sprint(t[1][0]), fmt.Sprint(t[1][1])}}
+}
+
+var TEST_RESULTS = []Result{
+	{
+		Day:         "day01",
+		Part:        1,
+		Answer:      11,
+		ParseTime:   2 * time.Millisecond,
+		SolveTime:   3 * time.Microsecond,
+		Input:       "example:example",
+		InputSha256: "abc",
+	},
+	{
+		Day:         "day01",
+		Part:        2,
+		Answer:      testTable{{0, 12}, {12, 0}},
+		ParseTime:   2 * time.Millisecond,
+		SolveTime:   40 * time.Microsecond,
+		Input:       "example:example",
+		InputSha256: "abc",
+	},
+}
+
+var TEST_BENCH = []BenchStats{
+	{Day: "day01", Phase: "parse", Runs: 10, Min: time.Millisecond, Median: 2 * time.Millisecond, P95: 5 * time.Millisecond, Allocs: 3, Bytes: 1024},
+}
+
+func writeOutput(t *testing.T, format string, results []Result, bench []BenchStats) string {
+	var buffer bytes.Buffer
+	out, err := NewResultWriter(format, &buffer)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, result := range results {
+		if err = out.Write(result); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for _, stats := range bench {
+		if err = out.WriteBench(stats); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err = out.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	return buffer.String()
+}
+
+func TestResultWriter(t *testing.T) {
+	tests := []struct {
+		name     string
+		format   string
+		results  []Result
+		bench    []BenchStats
+		expected string
+	}{
+		{
+			name:    "text",
+			format:  FORMAT_TEXT,
+			results: TEST_RESULTS,
+			expected: `DAY    PART  ANSWER     PARSE  SOLVE
+day01  1     11         2ms    3µs
+day01  2     2x3 table  2ms    40µs
+
+day01 part 2
+      a   b
+  a   0  12
+  b  12   0
+`,
+		},
+		{
+			name:   "text bench",
+			format: FORMAT_TEXT,
+			bench:  TEST_BENCH,
+			expected: `DAY    PHASE  RUNS  MIN  MEDIAN  P95  ALLOCS/RUN  BYTES/RUN
+day01  parse  10    1ms  2ms     5ms  3           1024
+`,
+		},
+		{
+			name:    "json",
+			format:  FORMAT_JSON,
+			results: TEST_RESULTS,
+			expected: `[
+  {
+    "day": "day01",
+    "part": 1,
+    "answer": "11",
+    "parse_time_ns": 2000000,
+    "solve_time_ns": 3000,
+    "input": "example:example",
+    "input_sha256": "abc"
+  },
+  {
+    "day": "day01",
+    "part": 2,
+    "answer": "2x3 table",
+    "parse_time_ns": 2000000,
+    "solve_time_ns": 40000,
+    "input": "example:example",
+    "input_sha256": "abc",
+    "table": [
+      [
+        "",
+        "a",
+        "b"
+      ],
+      [
+        "a",
+        "0",
+        "12"
+      ],
+      [
+        "b",
+        "12",
+        "0"
+      ]
+    ]
+  }
+]
+`,
+		},
+		{
+			name:   "json bench",
+			format: FORMAT_JSON,
+			bench:  TEST_BENCH,
+			expected: `[
+  {
+    "day": "day01",
+    "phase": "parse",
+    "runs": 10,
+    "min_ns": 1000000,
+    "median_ns": 2000000,
+    "p95_ns": 5000000,
+    "allocs": 3,
+    "bytes": 1024
+  }
+]
+`,
+		},
+		{
+			name:     "json without results",
+			format:   FORMAT_JSON,
+			expected: "[]\n",
+		},
+		{
+			name:    "ndjson",
+			format:  FORMAT_NDJSON,
+			results: TEST_RESULTS,
+			bench:   TEST_BENCH,
+			expected: `{"day":"day01","part":1,"answer":"11","parse_time_ns":2000000,"solve_time_ns":3000,"input":"example:example","input_sha256":"abc"}
+{"day":"day01","part":2,"answer":"2x3 table","parse_time_ns":2000000,"solve_time_ns":40000,"input":"example:example","input_sha256":"abc","table":[["","a","b"],["a","0","12"],["b","12","0"]]}
+{"day":"day01","phase":"parse","runs":10,"min_ns":1000000,"median_ns":2000000,"p95_ns":5000000,"allocs":3,"bytes":1024}
+`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			actual := writeOutput(t, test.format, test.results, test.bench)
+			if actual != test.expected {
+				t.Errorf("Expected:\n%s\nGot:\n%s", test.expected, actual)
+			}
+		})
+	}
+}
+
+func TestUnknownFormat(t *testing.T) {
+	_, err := NewResultWriter("yaml", &bytes.Buffer{})
+	if err == nil {
+		t.Fatal("Expected an error")
+	}
+
+	if err.Error() != `Unknown output format "yaml"` {
+		t.Errorf("Unexpected error %s", err)
+	}
+}
@@ -0,0 +1,101 @@
+package aoc
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"runtime"
+	"runtime/pprof"
+	"runtime/trace"
+
+	"github.com/charmbracelet/log"
+)
+
+type ProfileOptions struct {
+	CpuProfile   string `long:"cpuprofile" value-name:"FILE" description:"Write a CPU profile for go tool pprof"`
+	MemProfile   string `long:"memprofile" value-name:"FILE" description:"Write a heap profile for go tool pprof once the solvers finish"`
+	BlockProfile string `long:"blockprofile" value-name:"FILE" description:"Write a profile of blocking on locks and channels for go tool pprof"`
+	Trace        string `long:"trace" value-name:"FILE" description:"Write an execution trace for go tool trace"`
+}
+
+// Start begins the requested profiles, the returned function writes them out
+// and must be called once the solvers have finished
+func (o *ProfileOptions) Start() (func() error, error) {
+	stops := make([]func() error, 0)
+	stop := func() error {
+		errs := make([]error, 0, len(stops))
+		for i := len(stops) - 1; i >= 0; i-- {
+			errs = append(errs, stops[i]())
+		}
+		return errors.Join(errs...)
+	}
+
+	if o.CpuProfile != "" {
+		file, err := os.Create(o.CpuProfile)
+		if err != nil {
+			return nil, errors.Join(fmt.Errorf("Cannot create the CPU profile: %w", err), stop())
+		}
+
+		if err = pprof.StartCPUProfile(file); err != nil {
+			file.Close()
+			return nil, errors.Join(fmt.Errorf("Cannot start the CPU profile: %w", err), stop())
+		}
+
+		log.Info("Recording a CPU profile", "path", o.CpuProfile)
+		stops = append(stops, func() error {
+			pprof.StopCPUProfile()
+			return file.Close()
+		})
+	}
+
+	if o.Trace != "" {
+		file, err := os.Create(o.Trace)
+		if err != nil {
+			return nil, errors.Join(fmt.Errorf("Cannot create the trace: %w", err), stop())
+		}
+
+		if err = trace.Start(file); err != nil {
+			file.Close()
+			return nil, errors.Join(fmt.Errorf("Cannot start the trace: %w", err), stop())
+		}
+
+		log.Info("Recording a trace", "path", o.Trace)
+		stops = append(stops, func() error {
+			trace.Stop()
+			return file.Close()
+		})
+	}
+
+	if o.BlockProfile != "" {
+		runtime.SetBlockProfileRate(1)
+		stops = append(stops, func() error {
+			defer runtime.SetBlockProfileRate(0)
+			return writeProfile("block", o.BlockProfile)
+		})
+	}
+
+	if o.MemProfile != "" {
+		stops = append(stops, func() error {
+			// Update the heap profile with everything that has been freed
+			runtime.GC()
+			return writeProfile("heap", o.MemProfile)
+		})
+	}
+
+	return stop, nil
+}
+
+func writeProfile(name, path string) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("Cannot create the %s profile: %w", name, err)
+	}
+
+	if err = pprof.Lookup(name).WriteTo(file, 0); err != nil {
+		file.Close()
+		return fmt.Errorf("Cannot write the %s profile: %w", name, err)
+	}
+
+	log.Info("Wrote profile", "profile", name, "path", path)
+	return file.Close()
+}
@@ -0,0 +1,45 @@
+package aoc
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestProfiles(t *testing.T) {
+	dir := t.TempDir()
+	opts := ProfileOptions{
+		CpuProfile:   filepath.Join(dir, "cpu.out"),
+		MemProfile:   filepath.Join(dir, "mem.out"),
+		BlockProfile: filepath.Join(dir, "block.out"),
+		Trace:        filepath.Join(dir, "trace.out"),
+	}
+
+	stop, err := opts.Start()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err = stop(); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, path := range []string{opts.CpuProfile, opts.MemProfile, opts.BlockProfile, opts.Trace} {
+		info, err := os.Stat(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if info.Size() == 0 {
+			t.Errorf("Expected %s to have been written", path)
+		}
+	}
+}
+
+func TestProfilesCannotCreate(t *testing.T) {
+	opts := ProfileOptions{CpuProfile: filepath.Join(t.TempDir(), "missing", "cpu.out")}
+
+	if _, err := opts.Start(); err == nil {
+		t.Error("Expected an error when the profile cannot be created")
+	}
+}
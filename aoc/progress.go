@@ -0,0 +1,148 @@
+package aoc
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/charmbracelet/log"
+	"github.com/mattn/go-isatty"
+)
+
+const (
+	PROGRESS_BAR_WIDTH    = 30
+	PROGRESS_BAR_INTERVAL = 100 * time.Millisecond
+	PROGRESS_LOG_INTERVAL = 5 * time.Second
+)
+
+type progressKey struct{}
+
+type progressOutput struct {
+	writer io.Writer
+	// A bar is drawn on a terminal, otherwise progress is logged
+	tty bool
+}
+
+// withProgress shows the progress that solvers report with StartProgress on
+// stderr
+func withProgress(ctx context.Context) context.Context {
+	tty := isatty.IsTerminal(os.Stderr.Fd()) || isatty.IsCygwinTerminal(os.Stderr.Fd())
+	return context.WithValue(ctx, progressKey{}, &progressOutput{writer: os.Stderr, tty: tty})
+}
+
+// Progress tracks how many units of work, i.e: obstacles tried or rows
+// scanned, a solver has done. It is safe to use from many goroutines.
+type Progress struct {
+	name    string
+	total   int64
+	done    atomic.Int64
+	start   time.Time
+	output  *progressOutput
+	stop    chan struct{}
+	stopped sync.WaitGroup
+}
+
+// StartProgress starts reporting progress through total units of work, Done
+// must be called when the work is finished. Nothing is shown when the runner
+// has not enabled progress, i.e: in tests and benchmarks.
+func StartProgress(ctx context.Context, name string, total int) *Progress {
+	p := &Progress{name: name, total: int64(total), start: time.Now()}
+
+	output, ok := ctx.Value(progressKey{}).(*progressOutput)
+	if !ok || total <= 0 || log.GetLevel() > log.InfoLevel {
+		return p
+	}
+
+	p.output = output
+	p.stop = make(chan struct{})
+	p.stopped.Add(1)
+	go p.render()
+
+	return p
+}
+
+// Add records n more units of work as done
+func (p *Progress) Add(n int) {
+	p.done.Add(int64(n))
+}
+
+func (p *Progress) Done() {
+	if p.stop == nil {
+		return
+	}
+
+	close(p.stop)
+	p.stopped.Wait()
+}
+
+func (p *Progress) render() {
+	defer p.stopped.Done()
+
+	interval := PROGRESS_LOG_INTERVAL
+	if p.output.tty {
+		interval = PROGRESS_BAR_INTERVAL
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case now := <-ticker.C:
+			done := p.done.Load()
+			if p.output.tty {
+				fmt.Fprintf(p.output.writer, "\r%s\033[K", p.bar(done, now))
+			} else {
+				log.Info("Progress",
+					"task", p.name,
+					"done", done,
+					"total", p.total,
+					"percent", fmt.Sprintf("%.1f", p.fraction(done)*100),
+					"eta", p.eta(done, now))
+			}
+		case <-p.stop:
+			if p.output.tty {
+				fmt.Fprint(p.output.writer, "\r\033[K")
+			}
+			return
+		}
+	}
+}
+
+func (p *Progress) fraction(done int64) float64 {
+	return min(float64(done)/float64(p.total), 1)
+}
+
+// eta assumes that the remaining work goes at the same rate as the work so far
+func (p *Progress) eta(done int64, now time.Time) time.Duration {
+	if done <= 0 {
+		return 0
+	}
+
+	elapsed := now.Sub(p.start)
+	remaining := max(p.total-done, 0)
+	return (elapsed * time.Duration(remaining) / time.Duration(done)).Round(time.Second)
+}
+
+func (p *Progress) bar(done int64, now time.Time) string {
+	filled := int(p.fraction(done) * PROGRESS_BAR_WIDTH)
+
+	eta := "?"
+	if done > 0 {
+		eta = p.eta(done, now).String()
+	}
+
+	return fmt.Sprintf("%s [%s%s] %3.0f%% %d/%d ETA %s",
+		p.name,
+		strings.Repeat("=", filled),
+		strings.Repeat(" ", PROGRESS_BAR_WIDTH-filled),
+		p.fraction(done)*100,
+		done,
+		p.total,
+		eta)
+}
@@ -0,0 +1,55 @@
+package aoc
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+)
+
+func TestProgressBar(t *testing.T) {
+	start := time.Date(2024, 12, 6, 5, 0, 0, 0, time.UTC)
+	p := &Progress{name: "obstacles", total: 200, start: start}
+
+	tests := []struct {
+		name     string
+		done     int64
+		elapsed  time.Duration
+		expected string
+	}{
+		{name: "not started", done: 0, elapsed: time.Second, expected: "obstacles [                              ]   0% 0/200 ETA ?"},
+		{name: "half way", done: 100, elapsed: 10 * time.Second, expected: "obstacles [===============               ]  50% 100/200 ETA 10s"},
+		{name: "over the total", done: 250, elapsed: time.Minute, expected: "obstacles [==============================] 100% 250/200 ETA 0s"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if actual := p.bar(test.done, start.Add(test.elapsed)); actual != test.expected {
+				t.Errorf("Expected %q, got %q", test.expected, actual)
+			}
+		})
+	}
+}
+
+func TestProgressDisabled(t *testing.T) {
+	p := StartProgress(context.Background(), "rows", 10)
+	p.Add(3)
+	p.Done()
+
+	if p.done.Load() != 3 {
+		t.Errorf("Expected 3 units of work, got %d", p.done.Load())
+	}
+}
+
+func TestProgressClearsTheBar(t *testing.T) {
+	var buffer bytes.Buffer
+	ctx := context.WithValue(context.Background(), progressKey{}, &progressOutput{writer: &buffer, tty: true})
+
+	p := StartProgress(ctx, "rows", 10)
+	p.Add(10)
+	p.Done()
+
+	if !bytes.HasSuffix(buffer.Bytes(), []byte("\r\033[K")) {
+		t.Errorf("Expected the bar to be cleared, got %q", buffer.String())
+	}
+}
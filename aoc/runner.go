@@ -0,0 +1,231 @@
+package aoc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/charmbracelet/log"
+	"github.com/jessevdk/go-flags"
+)
+
+const PART_ALL = "all"
+
+type Options struct {
+	Part   string `short:"p" long:"part" choice:"1" choice:"2" choice:"all" default:"all" description:"Which part of the puzzle to solve"`
+	Input  string `short:"i" long:"input" default:"input.txt" description:"Path to the puzzle input, - for stdin or example:<name> for an example input"`
+	Format string `short:"f" long:"format" choice:"text" choice:"json" choice:"ndjson" default:"text" description:"Format of the results written to stdout"`
+
+	Answers string `long:"answers" default:"answers.json" description:"Path to the file of recorded answers"`
+	Verify  bool   `long:"verify" description:"Fail when an answer does not match the recorded answer for the input"`
+	Record  bool   `long:"record" description:"Record the answers for the input as the correct answers"`
+
+	Bench int `long:"bench" value-name:"N" description:"Parse and solve the input N times and report timing statistics instead of the answers"`
+
+	Timeout time.Duration `long:"timeout" value-name:"DURATION" description:"Give up on a day when it takes longer than this, i.e: 30s"`
+
+	Profile ProfileOptions `group:"Profiling Options"`
+}
+
+// Parts returns the parts selected by the --part option in the order that
+// they should be solved
+func (o *Options) Parts() ([]int, error) {
+	switch o.Part {
+	case "1":
+		return []int{1}, nil
+	case "2":
+		return []int{2}, nil
+	case PART_ALL:
+		return []int{1, 2}, nil
+	default:
+		return nil, fmt.Errorf("Unknown part %q, expected 1, 2 or %s", o.Part, PART_ALL)
+	}
+}
+
+// interruptContext is cancelled by Ctrl-C so that long running solvers stop
+// rather than having to be killed
+func interruptContext() (context.Context, context.CancelFunc) {
+	return signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+}
+
+// Main is the entrypoint for a day's binary, it parses the cli args, reads
+// the input, then solves and reports the requested parts.
+func Main(day *Day) {
+	var opts Options
+	var logOpts LogOptions
+
+	parser := flags.NewParser(&opts, flags.Default)
+	if _, err := parser.AddGroup("Logging Options", "", &logOpts); err != nil {
+		log.Fatal("Cannot register options", "err", err)
+	}
+
+	if day.Options() != nil {
+		if _, err := parser.AddGroup(day.Name+" Options", "", day.Options()); err != nil {
+			log.Fatal("Cannot register options", "day", day.Name, "err", err)
+		}
+	}
+
+	args, err := parser.Parse()
+	if err != nil {
+		if flags.WroteHelp(err) {
+			os.Exit(0)
+		}
+		log.Fatal("Cannot parse cli args", "err", err)
+	}
+
+	if err = logOpts.Apply(); err != nil {
+		log.Fatal("Cannot configure logging", "err", err)
+	}
+
+	if len(args) > 0 {
+		log.Fatal("Unexpected positional arguments", "args", strings.Join(args, " "))
+	}
+
+	out, err := NewResultWriter(opts.Format, os.Stdout)
+	if err != nil {
+		log.Fatal("Cannot parse cli args", "err", err)
+	}
+
+	stopProfiling, err := opts.Profile.Start()
+	if err != nil {
+		log.Fatal("Cannot start profiling", "err", err)
+	}
+
+	ctx, stop := interruptContext()
+	defer stop()
+
+	err = run(ctx, day, &opts, out)
+	if closeErr := out.Close(); err == nil {
+		err = closeErr
+	}
+
+	if profileErr := stopProfiling(); err == nil {
+		err = profileErr
+	}
+
+	if err != nil {
+		log.Fatal("Cannot solve puzzle", "day", day.Name, "err", err)
+	}
+}
+
+func run(ctx context.Context, day *Day, opts *Options, out ResultWriter) error {
+	parts, err := opts.Parts()
+	if err != nil {
+		return err
+	}
+
+	if opts.Bench > 0 && (opts.Verify || opts.Record) {
+		return errors.New("Answers cannot be verified or recorded while benchmarking")
+	}
+
+	// The recorded answers are for the default options
+	if day.OptionsChanged() && (opts.Verify || opts.Record) {
+		return fmt.Errorf("Answers cannot be verified or recorded when the %s options are changed", day.Name)
+	}
+
+	if opts.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeoutCause(ctx, opts.Timeout, ErrTimeout)
+		defer cancel()
+	}
+
+	log.Info("Solving", "day", day.Name, "parts", parts)
+
+	log.Info("Reading data....", "input", opts.Input)
+	input, err := day.ReadInput(opts.Input)
+	if err != nil {
+		return err
+	}
+
+	if opts.Bench > 0 {
+		return runBench(ctx, day, input, parts, opts.Bench, out)
+	}
+
+	var answers *AnswersFile
+	if opts.Verify || opts.Record {
+		answers, err = day.LoadAnswers(opts.Answers)
+		if err != nil {
+			return err
+		}
+	}
+
+	ctx = withProgress(ctx)
+
+	log.Info("Parsing data...", "input", input.Name)
+	data, parseTime, err := day.Parse(input.Data)
+	if err != nil {
+		return fmt.Errorf("Cannot parse data: %w", input.annotate(err))
+	}
+
+	for _, part := range parts {
+		log.Info("Calculating...", "part", part)
+		result, err := day.Solve(ctx, part, data)
+		if errors.Is(err, ErrTimeout) {
+			return fmt.Errorf("Cannot solve part %d within %s: %w", part, opts.Timeout, err)
+		}
+		if err != nil {
+			return fmt.Errorf("Cannot solve part %d: %w", part, err)
+		}
+
+		result.ParseTime = parseTime
+		result.Input = input.Name
+		result.InputSha256 = input.Sha256()
+		log.Info("Complete!",
+			"part", result.Part,
+			"output", result.Answer,
+			"parse", result.ParseTime,
+			"solve", result.SolveTime)
+
+		if err = out.Write(result); err != nil {
+			return fmt.Errorf("Cannot write result: %w", err)
+		}
+
+		if opts.Verify {
+			checked, err := answers.Verify(result)
+			if err != nil {
+				return err
+			}
+
+			if checked {
+				log.Info("Answer matches the recorded answer", "part", part)
+			} else {
+				log.Warn("There is no recorded answer to verify against", "part", part, "answers", answers.Path)
+			}
+		}
+
+		if opts.Record {
+			answers.Record(result)
+		}
+	}
+
+	if opts.Record {
+		log.Info("Recording answers", "answers", answers.Path)
+		return answers.Save()
+	}
+
+	return nil
+}
+
+func runBench(ctx context.Context, day *Day, input Input, parts []int, runs int, out ResultWriter) error {
+	log.Info("Benchmarking...", "input", input.Name, "runs", runs)
+
+	restore := QuietLogging()
+	stats, err := day.Bench(ctx, input, parts, runs)
+	restore()
+	if err != nil {
+		return err
+	}
+
+	for _, phase := range stats {
+		if err = out.WriteBench(phase); err != nil {
+			return fmt.Errorf("Cannot write benchmark: %w", err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,137 @@
+package aoc
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+)
+
+func newRunnerTestDay(solves *int) *Day {
+	day := NewDay("day06", countingSolver{solves: solves})
+	day.Examples = fstest.MapFS{"examples/example.txt": {Data: []byte("example\n")}}
+	return day
+}
+
+func newRunnerTestOptions(t *testing.T) *Options {
+	return &Options{
+		Part:    PART_ALL,
+		Input:   INPUT_EXAMPLE_PREFIX + "example",
+		Format:  FORMAT_TEXT,
+		Answers: filepath.Join(t.TempDir(), ANSWERS_DEFAULT),
+	}
+}
+
+func TestRunBenchRejectsAnswers(t *testing.T) {
+	tests := []struct {
+		name   string
+		verify bool
+		record bool
+	}{
+		{name: "verify", verify: true},
+		{name: "record", record: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			solves := 0
+			opts := newRunnerTestOptions(t)
+			opts.Bench = 3
+			opts.Verify = test.verify
+			opts.Record = test.record
+
+			out, err := NewResultWriter(FORMAT_TEXT, &bytes.Buffer{})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if err = run(context.Background(), newRunnerTestDay(&solves), opts, out); err == nil {
+				t.Fatal("Expected an error")
+			}
+
+			if solves != 0 {
+				t.Errorf("Expected nothing to be solved, solved %d times", solves)
+			}
+		})
+	}
+}
+
+type testOptions struct {
+	Metric string
+}
+
+func TestRunChangedOptionsRejectsAnswers(t *testing.T) {
+	tests := []struct {
+		name          string
+		metric        string
+		verify        bool
+		record        bool
+		expectedError bool
+	}{
+		{name: "verify", metric: "l2", verify: true, expectedError: true},
+		{name: "record", metric: "l2", record: true, expectedError: true},
+		{name: "run", metric: "l2"},
+		{name: "record defaults", metric: "l1", record: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			solves := 0
+			day := newRunnerTestDay(&solves)
+			options := &testOptions{Metric: "l1"}
+			day.SetOptions(options)
+			options.Metric = test.metric
+
+			opts := newRunnerTestOptions(t)
+			opts.Verify = test.verify
+			opts.Record = test.record
+
+			out, err := NewResultWriter(FORMAT_TEXT, &bytes.Buffer{})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			err = run(context.Background(), day, opts, out)
+			if !test.expectedError {
+				if err != nil {
+					t.Fatal(err)
+				}
+				return
+			}
+
+			if err == nil {
+				t.Fatal("Expected an error")
+			}
+
+			if solves != 0 {
+				t.Errorf("Expected nothing to be solved, solved %d times", solves)
+			}
+
+			if _, err = os.Stat(opts.Answers); !errors.Is(err, fs.ErrNotExist) {
+				t.Errorf("Expected no answers to be recorded, got %v", err)
+			}
+		})
+	}
+}
+
+func TestOptionsChanged(t *testing.T) {
+	day := newRunnerTestDay(new(int))
+	if day.OptionsChanged() {
+		t.Error("Expected a day without options to be unchanged")
+	}
+
+	options := &testOptions{Metric: "l1"}
+	day.SetOptions(options)
+	if day.OptionsChanged() {
+		t.Error("Expected the defaults to be unchanged")
+	}
+
+	options.Metric = "l2"
+	if !day.OptionsChanged() {
+		t.Error("Expected the options to be changed")
+	}
+}
@@ -0,0 +1,180 @@
+package aoc
+
+import (
+	"bytes"
+	"embed"
+	"errors"
+	"fmt"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/charmbracelet/log"
+)
+
+const (
+	MODULE_PREFIX   = "github.com/djpiper28/advent-of-code-2024/"
+	DISPATCHER_DAYS = "cmd/aoc/days.go"
+	MAKEFILE        = "Makefile"
+)
+
+//go:embed templates
+var templates embed.FS
+
+var (
+	dayNameRegex     = regexp.MustCompile(`^day[0-9]{2}$`)
+	makefileDaysLine = regexp.MustCompile(`(?m)^GO_DAYS=(.*)$`)
+)
+
+type scaffoldFile struct {
+	template string
+	path     string
+}
+
+type scaffoldData struct {
+	Name string
+}
+
+// Scaffold creates a new day's package under root, then registers it with the
+// dispatcher and the Makefile
+func Scaffold(root, name string) error {
+	if !dayNameRegex.MatchString(name) {
+		return fmt.Errorf("%q is not a valid day name, expected dayNN", name)
+	}
+
+	dir := filepath.Join(root, name)
+	if _, err := os.Stat(dir); !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("%s already exists", dir)
+	}
+
+	if _, err := os.Stat(filepath.Join(root, DISPATCHER_DAYS)); err != nil {
+		return fmt.Errorf("%s does not look like the root of the repo: %w", root, err)
+	}
+
+	files := []scaffoldFile{
+		{template: "gitignore.tmpl", path: ".gitignore"},
+		{template: "day.go.tmpl", path: name + ".go"},
+		{template: "day_test.go.tmpl", path: name + "_test.go"},
+		{template: "main.go.tmpl", path: filepath.Join("cmd", name, "main.go")},
+	}
+
+	data := scaffoldData{Name: name}
+	for _, file := range files {
+		log.Info("Creating file", "path", filepath.Join(dir, file.path))
+		if err := renderTemplate(file.template, data, filepath.Join(dir, file.path)); err != nil {
+			return err
+		}
+	}
+
+	examples := filepath.Join(dir, EXAMPLES_DIR, "example.txt")
+	if err := os.MkdirAll(filepath.Dir(examples), 0o755); err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(examples, []byte{}, 0o644); err != nil {
+		return err
+	}
+
+	if err := registerWithDispatcher(root, name); err != nil {
+		return err
+	}
+
+	return registerWithMakefile(root, name)
+}
+
+func renderTemplate(name string, data any, dest string) error {
+	tmpl, err := template.ParseFS(templates, path.Join("templates", name))
+	if err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	if err = tmpl.Execute(&buf, data); err != nil {
+		return fmt.Errorf("Cannot render %s: %w", name, err)
+	}
+
+	output := buf.Bytes()
+	if strings.HasSuffix(dest, ".go") {
+		output, err = format.Source(output)
+		if err != nil {
+			return fmt.Errorf("Cannot format %s: %w", dest, err)
+		}
+	}
+
+	if err = os.MkdirAll(filepath.Dir(dest), 0o755); err != nil {
+		return err
+	}
+
+	return os.WriteFile(dest, output, 0o644)
+}
+
+// registeredDays reads the days imported by the dispatcher
+func registeredDays(daysFile string) ([]string, error) {
+	file, err := parser.ParseFile(token.NewFileSet(), daysFile, nil, parser.ImportsOnly)
+	if err != nil {
+		return nil, fmt.Errorf("Cannot parse %s: %w", daysFile, err)
+	}
+
+	days := make([]string, 0)
+	for _, spec := range file.Imports {
+		importPath, err := strconv.Unquote(spec.Path.Value)
+		if err != nil {
+			return nil, err
+		}
+
+		name, found := strings.CutPrefix(importPath, MODULE_PREFIX)
+		if found && dayNameRegex.MatchString(name) {
+			days = append(days, name)
+		}
+	}
+
+	return days, nil
+}
+
+func registerWithDispatcher(root, name string) error {
+	daysFile := filepath.Join(root, DISPATCHER_DAYS)
+	log.Info("Registering with the dispatcher", "path", daysFile)
+
+	days, err := registeredDays(daysFile)
+	if err != nil {
+		return err
+	}
+
+	days = append(days, name)
+	slices.Sort(days)
+	days = slices.Compact(days)
+
+	return renderTemplate("days.go.tmpl", days, daysFile)
+}
+
+func registerWithMakefile(root, name string) error {
+	makefile := filepath.Join(root, MAKEFILE)
+	log.Info("Registering with the Makefile", "path", makefile)
+
+	data, err := os.ReadFile(makefile)
+	if err != nil {
+		return err
+	}
+
+	match := makefileDaysLine.FindSubmatch(data)
+	if match == nil {
+		return fmt.Errorf("Cannot find the GO_DAYS list in %s", makefile)
+	}
+
+	days := strings.Fields(string(match[1]))
+	days = append(days, name)
+	slices.Sort(days)
+	days = slices.Compact(days)
+
+	data = makefileDaysLine.ReplaceAll(data, []byte("GO_DAYS="+strings.Join(days, " ")))
+	return os.WriteFile(makefile, data, 0o644)
+}
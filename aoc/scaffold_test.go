@@ -0,0 +1,119 @@
+package aoc
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+const TEST_DAYS_FILE = `package main
+
+import (
+	"github.com/djpiper28/advent-of-code-2024/aoc"
+	"github.com/djpiper28/advent-of-code-2024/day04"
+	"github.com/djpiper28/advent-of-code-2024/day01"
+)
+
+func days() []*aoc.Day {
+	return []*aoc.Day{
+		day04.Day(),
+		day01.Day(),
+	}
+}
+`
+
+const TEST_MAKEFILE = `GO_DAYS=day04 day01 day04
+TARGETS=$(patsubst %,%-exec,$(GO_DAYS))
+`
+
+func newTestRepo(t *testing.T) string {
+	root := t.TempDir()
+	writeFile(t, filepath.Join(root, DISPATCHER_DAYS), TEST_DAYS_FILE)
+	writeFile(t, filepath.Join(root, MAKEFILE), TEST_MAKEFILE)
+	return root
+}
+
+func TestScaffold(t *testing.T) {
+	root := newTestRepo(t)
+
+	if err := Scaffold(root, "day03"); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, path := range []string{".gitignore", "day03.go", "day03_test.go", "cmd/day03/main.go", "examples/example.txt"} {
+		if _, err := os.Stat(filepath.Join(root, "day03", path)); err != nil {
+			t.Errorf("Expected %s to be created: %s", path, err)
+		}
+	}
+
+	days, err := registeredDays(filepath.Join(root, DISPATCHER_DAYS))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if expected := []string{"day01", "day03", "day04"}; !slices.Equal(days, expected) {
+		t.Errorf("Expected the dispatcher to import %v, got %v", expected, days)
+	}
+
+	daysFile, err := os.ReadFile(filepath.Join(root, DISPATCHER_DAYS))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.Contains(string(daysFile), "day03.Day(),") {
+		t.Errorf("Expected the dispatcher to register day03, got:\n%s", daysFile)
+	}
+
+	makefile, err := os.ReadFile(filepath.Join(root, MAKEFILE))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := "GO_DAYS=day01 day03 day04\nTARGETS=$(patsubst %,%-exec,$(GO_DAYS))\n"
+	if string(makefile) != expected {
+		t.Errorf("Expected the Makefile:\n%s\nGot:\n%s", expected, makefile)
+	}
+}
+
+func TestScaffoldRejects(t *testing.T) {
+	tests := []struct {
+		name string
+		day  string
+	}{
+		{name: "existing directory", day: "day04"},
+		{name: "no number", day: "dayx"},
+		{name: "one digit", day: "day7"},
+		{name: "not a day", day: "week07"},
+		{name: "path", day: "../day07"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			root := newTestRepo(t)
+			if err := os.Mkdir(filepath.Join(root, "day04"), 0o755); err != nil {
+				t.Fatal(err)
+			}
+
+			if err := Scaffold(root, test.day); err == nil {
+				t.Fatal("Expected an error")
+			}
+
+			makefile, err := os.ReadFile(filepath.Join(root, MAKEFILE))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if string(makefile) != TEST_MAKEFILE {
+				t.Errorf("Expected the Makefile to be unchanged, got:\n%s", makefile)
+			}
+		})
+	}
+}
+
+func TestScaffoldOutsideRepo(t *testing.T) {
+	if err := Scaffold(t.TempDir(), "day07"); err == nil {
+		t.Fatal("Expected an error")
+	}
+}
@@ -0,0 +1,136 @@
+package aoc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"reflect"
+	"time"
+)
+
+// ErrTimeout is the cause of a solve being cancelled by the --timeout option
+var ErrTimeout = errors.New("The time limit was reached")
+
+// Solver is implemented by each day, T is the parsed form of the puzzle input
+// and is shared between both parts. Long running parts should stop when the
+// context is cancelled.
+type Solver[T any] interface {
+	Parse(input []byte) (T, error)
+	Part1(ctx context.Context, data T) (any, error)
+	Part2(ctx context.Context, data T) (any, error)
+}
+
+// Day is a type erased Solver that the runner can drive without knowing what
+// the parsed data looks like.
+type Day struct {
+	Name string
+	// Embedded example inputs, stored as examples/<name>.txt
+	Examples fs.FS
+	options  any
+	defaults any
+	parse    func(input []byte) (any, error)
+	parts    [2]func(ctx context.Context, data any) (any, error)
+}
+
+func NewDay[T any](name string, solver Solver[T]) *Day {
+	return &Day{
+		Name: name,
+		parse: func(input []byte) (any, error) {
+			return solver.Parse(input)
+		},
+		parts: [2]func(ctx context.Context, data any) (any, error){
+			func(ctx context.Context, data any) (any, error) {
+				return solver.Part1(ctx, data.(T))
+			},
+			func(ctx context.Context, data any) (any, error) {
+				return solver.Part2(ctx, data.(T))
+			},
+		},
+	}
+}
+
+// SetOptions gives the day a pointer to a go-flags struct of options that
+// only apply to it. The struct must be created with its defaults rather than
+// using default tags, they are remembered so that changes can be detected.
+func (d *Day) SetOptions(options any) {
+	d.options = options
+	d.defaults = reflect.ValueOf(options).Elem().Interface()
+}
+
+// Options is the pointer given to SetOptions, or nil when the day has none
+func (d *Day) Options() any {
+	return d.options
+}
+
+// OptionsChanged is true when any of the day's options are not their default,
+// which can change the answers
+func (d *Day) OptionsChanged() bool {
+	if d.options == nil {
+		return false
+	}
+
+	return !reflect.DeepEqual(reflect.ValueOf(d.options).Elem().Interface(), d.defaults)
+}
+
+type Result struct {
+	Day         string
+	Part        int
+	Answer      any
+	ParseTime   time.Duration
+	SolveTime   time.Duration
+	Input       string
+	InputSha256 string
+}
+
+func (d *Day) Parse(input []byte) (any, time.Duration, error) {
+	start := time.Now()
+	data, err := d.parse(input)
+	return data, time.Since(start), err
+}
+
+// Solve runs one part, when the context is cancelled the error is the cause of
+// the cancellation, i.e: ErrTimeout or context.Canceled
+func (d *Day) Solve(ctx context.Context, part int, data any) (Result, error) {
+	if part < 1 || part > len(d.parts) {
+		return Result{}, fmt.Errorf("Part %d does not exist", part)
+	}
+
+	if ctx.Err() != nil {
+		return Result{}, context.Cause(ctx)
+	}
+
+	start := time.Now()
+	answer, err := d.parts[part-1](ctx, data)
+	if err != nil {
+		if ctx.Err() != nil {
+			return Result{}, context.Cause(ctx)
+		}
+		return Result{}, err
+	}
+
+	return Result{
+		Day:       d.Name,
+		Part:      part,
+		Answer:    answer,
+		SolveTime: time.Since(start),
+	}, nil
+}
+
+// SolveInput parses the input and solves a single part of it
+func (d *Day) SolveInput(ctx context.Context, input Input, part int) (Result, error) {
+	data, parseTime, err := d.Parse(input.Data)
+	if err != nil {
+		return Result{}, fmt.Errorf("Cannot parse data: %w", input.annotate(err))
+	}
+
+	result, err := d.Solve(ctx, part, data)
+	if err != nil {
+		return Result{}, fmt.Errorf("Cannot solve part %d: %w", part, err)
+	}
+
+	result.ParseTime = parseTime
+	result.Input = input.Name
+	result.InputSha256 = input.Sha256()
+	return result, nil
+}
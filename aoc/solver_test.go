@@ -0,0 +1,45 @@
+package aoc
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+type blockingSolver struct{}
+
+func (blockingSolver) Parse(input []byte) (int, error) {
+	return 0, nil
+}
+
+func (blockingSolver) Part1(ctx context.Context, data int) (any, error) {
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+func (blockingSolver) Part2(ctx context.Context, data int) (any, error) {
+	return nil, errors.New("Broken")
+}
+
+func TestSolveCancelled(t *testing.T) {
+	day := NewDay("day99", blockingSolver{})
+
+	ctx, cancel := context.WithTimeoutCause(context.Background(), 10*time.Millisecond, ErrTimeout)
+	defer cancel()
+
+	if _, err := day.Solve(ctx, 1, 0); !errors.Is(err, ErrTimeout) {
+		t.Errorf("Expected a timeout error, got %v", err)
+	}
+
+	ctx, cancel = context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := day.Solve(ctx, 1, 0); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected a cancelled error, got %v", err)
+	}
+
+	if _, err := day.Solve(context.Background(), 2, 0); err == nil || errors.Is(err, ErrTimeout) {
+		t.Errorf("Expected the solver's own error, got %v", err)
+	}
+}
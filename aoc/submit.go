@@ -0,0 +1,205 @@
+package aoc
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/log"
+)
+
+const SUBMISSIONS_FILE = "submissions.json"
+
+type Verdict string
+
+const (
+	VerdictCorrect       Verdict = "correct"
+	VerdictTooHigh       Verdict = "too high"
+	VerdictTooLow        Verdict = "too low"
+	VerdictWrong         Verdict = "wrong"
+	VerdictWait          Verdict = "wait"
+	VerdictAlreadySolved Verdict = "already solved"
+	VerdictUnknown       Verdict = "unknown"
+)
+
+var (
+	articleRegex  = regexp.MustCompile(`(?s)<article[^>]*>(.*?)</article>`)
+	tagRegex      = regexp.MustCompile(`<[^>]*>`)
+	leftWaitRegex = regexp.MustCompile(`(?i)you have (?:(\d+)m )?(\d+)s left to wait`)
+	waitRegex     = regexp.MustCompile(`(?i)please wait (one|\d+) minutes? before trying again`)
+)
+
+// Submission is a recorded answer and the website's verdict on it
+type Submission struct {
+	Part    int       `json:"part"`
+	Answer  string    `json:"answer"`
+	Verdict Verdict   `json:"verdict"`
+	Time    time.Time `json:"time"`
+	// How long the website said to wait before submitting again
+	Wait    time.Duration `json:"wait_ns"`
+	Message string        `json:"message"`
+}
+
+// ParseVerdict reads the verdict, and how long to wait before submitting
+// again, from the page returned after posting an answer
+func ParseVerdict(page string) (Verdict, time.Duration, string) {
+	message := page
+	if match := articleRegex.FindStringSubmatch(page); match != nil {
+		message = match[1]
+	}
+	message = strings.Join(strings.Fields(html.UnescapeString(tagRegex.ReplaceAllString(message, ""))), " ")
+
+	var wait time.Duration
+	if match := leftWaitRegex.FindStringSubmatch(message); match != nil {
+		minutes, _ := strconv.Atoi(match[1])
+		seconds, _ := strconv.Atoi(match[2])
+		wait = time.Duration(minutes)*time.Minute + time.Duration(seconds)*time.Second
+	} else if match := waitRegex.FindStringSubmatch(message); match != nil {
+		minutes := 1
+		if match[1] != "one" {
+			minutes, _ = strconv.Atoi(match[1])
+		}
+		wait = time.Duration(minutes) * time.Minute
+	}
+
+	switch {
+	case strings.Contains(message, "That's the right answer"):
+		return VerdictCorrect, wait, message
+	case strings.Contains(message, "You gave an answer too recently"):
+		return VerdictWait, wait, message
+	case strings.Contains(message, "You don't seem to be solving the right level"):
+		return VerdictAlreadySolved, wait, message
+	case strings.Contains(message, "your answer is too high"):
+		return VerdictTooHigh, wait, message
+	case strings.Contains(message, "your answer is too low"):
+		return VerdictTooLow, wait, message
+	case strings.Contains(message, "That's not the right answer"):
+		return VerdictWrong, wait, message
+	default:
+		return VerdictUnknown, wait, message
+	}
+}
+
+type SubmissionLog struct {
+	Path        string
+	Submissions []Submission
+}
+
+func (c *Client) LoadSubmissions(day int) (*SubmissionLog, error) {
+	ret := &SubmissionLog{
+		Path:        filepath.Join(c.DayDir(day), SUBMISSIONS_FILE),
+		Submissions: make([]Submission, 0),
+	}
+
+	data, err := os.ReadFile(ret.Path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return ret, nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("Cannot read submissions: %w", err)
+	}
+
+	if err = json.Unmarshal(data, &ret.Submissions); err != nil {
+		return nil, fmt.Errorf("Cannot parse submissions %s: %w", ret.Path, err)
+	}
+
+	return ret, nil
+}
+
+func (l *SubmissionLog) Save() error {
+	data, err := json.MarshalIndent(l.Submissions, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return writePrivateFile(l.Path, append(data, '\n'))
+}
+
+// CooldownUntil is when the website will next accept an answer
+func (l *SubmissionLog) CooldownUntil() time.Time {
+	var until time.Time
+	for _, submission := range l.Submissions {
+		end := submission.Time.Add(submission.Wait)
+		if end.After(until) {
+			until = end
+		}
+	}
+
+	return until
+}
+
+func (c *Client) now() time.Time {
+	if c.Now != nil {
+		return c.Now()
+	}
+	return time.Now()
+}
+
+// Submit posts the answer to the website, refusing to do so while the last
+// submission's cooldown has not passed. Answers that are known to be wrong
+// from earlier guesses are refused unless force is set. The verdict is
+// recorded in the day's submission log.
+func (c *Client) Submit(day, part int, answer string, force bool) (Submission, error) {
+	submissions, err := c.LoadSubmissions(day)
+	if err != nil {
+		return Submission{}, err
+	}
+
+	if err = submissions.Check(part, answer); err != nil {
+		if !force {
+			return Submission{}, err
+		}
+		log.Warn("Submitting anyway", "reason", err)
+	}
+
+	if until := submissions.CooldownUntil(); c.now().Before(until) {
+		return Submission{}, fmt.Errorf("You must wait %s before submitting again",
+			until.Sub(c.now()).Round(time.Second))
+	}
+
+	form := url.Values{}
+	form.Set("level", strconv.Itoa(part))
+	form.Set("answer", answer)
+
+	endpoint := fmt.Sprintf("%s/%d/day/%d/answer", c.BaseURL, YEAR, day)
+	log.Info("Submitting answer", "url", endpoint, "part", part, "answer", answer)
+
+	req, err := http.NewRequest(http.MethodPost, endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return Submission{}, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	page, err := c.do(req)
+	if err != nil {
+		return Submission{}, fmt.Errorf("Cannot submit answer: %w", err)
+	}
+
+	verdict, wait, message := ParseVerdict(string(page))
+	submission := Submission{
+		Part:    part,
+		Answer:  answer,
+		Verdict: verdict,
+		Time:    c.now(),
+		Wait:    wait,
+		Message: message,
+	}
+
+	submissions.Submissions = append(submissions.Submissions, submission)
+	if err = submissions.Save(); err != nil {
+		return submission, fmt.Errorf("Cannot record submission: %w", err)
+	}
+
+	return submission, nil
+}
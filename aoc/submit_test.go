@@ -0,0 +1,182 @@
+package aoc
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"testing/fstest"
+	"time"
+)
+
+func page(article string) string {
+	return `<html><body><main><article><p>` + article + `</p></article></main></body></html>`
+}
+
+func TestParseVerdict(t *testing.T) {
+	tests := []struct {
+		name     string
+		page     string
+		verdict  Verdict
+		expected time.Duration
+	}{
+		{
+			name:    "correct",
+			page:    page(`That's the right answer! You are <span class="day-success">one gold star</span> closer.`),
+			verdict: VerdictCorrect,
+		},
+		{
+			name:     "too high",
+			page:     page(`That's not the right answer; your answer is too high. Please wait one minute before trying again. <a href="/2024/day/6">[Return to Day 6]</a>`),
+			verdict:  VerdictTooHigh,
+			expected: time.Minute,
+		},
+		{
+			name:     "too low",
+			page:     page(`That's not the right answer; your answer is too low. Please wait 5 minutes before trying again.`),
+			verdict:  VerdictTooLow,
+			expected: 5 * time.Minute,
+		},
+		{
+			name:     "wrong",
+			page:     page(`That's not the right answer. If you're stuck, make sure you're using the full input data. Please wait one minute before trying again.`),
+			verdict:  VerdictWrong,
+			expected: time.Minute,
+		},
+		{
+			name:     "too recently",
+			page:     page(`You gave an answer too recently; you have to wait after submitting an answer before trying again. You have 4m 3s left to wait.`),
+			verdict:  VerdictWait,
+			expected: 4*time.Minute + 3*time.Second,
+		},
+		{
+			name:     "too recently seconds",
+			page:     page(`You gave an answer too recently; you have 42s left to wait.`),
+			verdict:  VerdictWait,
+			expected: 42 * time.Second,
+		},
+		{
+			name:    "already solved",
+			page:    page(`You don't seem to be solving the right level.  Did you already complete it?`),
+			verdict: VerdictAlreadySolved,
+		},
+		{
+			name:    "unknown",
+			page:    `<html>Something else</html>`,
+			verdict: VerdictUnknown,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			verdict, wait, _ := ParseVerdict(test.page)
+			if verdict != test.verdict {
+				t.Errorf("Expected %q, got %q", test.verdict, verdict)
+			}
+
+			if wait != test.expected {
+				t.Errorf("Expected to wait %s, got %s", test.expected, wait)
+			}
+		})
+	}
+}
+
+func TestSubmit(t *testing.T) {
+	requests := 0
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+
+		if r.Method != http.MethodPost || r.URL.Path != "/2024/day/6/answer" {
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+		}
+
+		if r.FormValue("level") != "2" || r.FormValue("answer") != "1234" {
+			t.Errorf("Unexpected form %v", r.Form)
+		}
+
+		w.Write([]byte(page(`That's not the right answer; your answer is too low. Please wait one minute before trying again.`)))
+	})
+
+	now := time.Date(2024, 12, 6, 6, 0, 0, 0, time.UTC)
+	client.Now = func() time.Time { return now }
+
+	submission, err := client.Submit(6, 2, "1234", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if submission.Verdict != VerdictTooLow || submission.Wait != time.Minute {
+		t.Errorf("Unexpected submission %+v", submission)
+	}
+
+	// The cooldown is enforced locally without asking the website
+	now = now.Add(30 * time.Second)
+	if _, err = client.Submit(6, 2, "1234", true); err == nil {
+		t.Error("Expected the cooldown to prevent submitting")
+	}
+
+	if requests != 1 {
+		t.Errorf("Expected 1 request, made %d", requests)
+	}
+
+	submissions, err := client.LoadSubmissions(6)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(submissions.Submissions) != 1 || submissions.Submissions[0].Answer != "1234" {
+		t.Errorf("Expected the submission to be recorded, got %+v", submissions.Submissions)
+	}
+
+	now = now.Add(time.Minute)
+	if _, err = client.Submit(6, 2, "1234", false); err == nil {
+		t.Error("Expected the repeated guess to be refused")
+	}
+
+	if _, err = client.Submit(6, 2, "1234", true); err != nil {
+		t.Errorf("Expected the cooldown to have passed, got %v", err)
+	}
+}
+
+// countingSolver counts how many times the parts are solved
+type countingSolver struct {
+	solves *int
+}
+
+func (s countingSolver) Parse(input []byte) (string, error) {
+	return string(input), nil
+}
+
+func (s countingSolver) Part1(_ context.Context, data string) (any, error) {
+	*s.solves++
+	return len(data), nil
+}
+
+func (s countingSolver) Part2(ctx context.Context, data string) (any, error) {
+	return s.Part1(ctx, data)
+}
+
+func TestSubmitRejectsExamples(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+	})
+
+	solves := 0
+	day := NewDay("day06", countingSolver{solves: &solves})
+	day.Examples = fstest.MapFS{"examples/example.txt": {Data: []byte("example\n")}}
+
+	command := &submitCommand{
+		ClientOptions: ClientOptions{BaseURL: client.BaseURL, Session: client.Session, CacheDir: client.CacheDir},
+		Input:         INPUT_EXAMPLE_PREFIX + "example",
+		days:          []*Day{day},
+	}
+	command.Args.Day = "day06"
+	command.Args.Part = 2
+
+	if err := command.Execute(nil); err == nil {
+		t.Fatal("Expected an error")
+	}
+
+	if solves != 0 {
+		t.Errorf("Expected the example not to be solved, solved it %d times", solves)
+	}
+}
@@ -0,0 +1,47 @@
+package tokenizer
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Diagnostic is a parse error at a position in the input, lines and columns
+// start at 1. Source is the offending line which is shown with a caret under
+// the column.
+type Diagnostic struct {
+	File         string
+	Line, Column int
+	Source       string
+	Err          error
+}
+
+func (d *Diagnostic) Error() string {
+	var builder strings.Builder
+	if d.File != "" {
+		fmt.Fprintf(&builder, "%s:", d.File)
+	}
+	fmt.Fprintf(&builder, "%d:%d: %s", d.Line, d.Column, d.Err)
+
+	if d.Source == "" && d.Column <= 1 {
+		return builder.String()
+	}
+
+	gutter := fmt.Sprint(d.Line)
+	fmt.Fprintf(&builder, "\n %s | %s\n %s | ", gutter, d.Source, strings.Repeat(" ", len(gutter)))
+
+	// Keep the tabs from the source so the caret lines up
+	for i := 0; i < d.Column-1; i++ {
+		if i < len(d.Source) && d.Source[i] == '\t' {
+			builder.WriteByte('\t')
+		} else {
+			builder.WriteByte(' ')
+		}
+	}
+	builder.WriteByte('^')
+
+	return builder.String()
+}
+
+func (d *Diagnostic) Unwrap() error {
+	return d.Err
+}
@@ -0,0 +1,293 @@
+package tokenizer
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+)
+
+// Tokenizer reads integers and fields from a stream. Spaces and tabs between
+// tokens are skipped, lines may end with LF or CRLF and the last line does not
+// need a new line.
+type Tokenizer struct {
+	reader       *bufio.Reader
+	line, column int
+	// The bytes read so far on the current line
+	current []byte
+	err     error
+}
+
+func New(reader io.Reader) *Tokenizer {
+	return &Tokenizer{reader: bufio.NewReader(reader), line: 1, column: 1}
+}
+
+// Position is the line and column of the next unread byte
+func (t *Tokenizer) Position() (line, column int) {
+	return t.line, t.column
+}
+
+// Err is the first error from the underlying reader, other than io.EOF
+func (t *Tokenizer) Err() error {
+	return t.err
+}
+
+func (t *Tokenizer) peek() (byte, bool) {
+	if t.err != nil {
+		return 0, false
+	}
+
+	b, err := t.reader.Peek(1)
+	if err != nil {
+		if !errors.Is(err, io.EOF) {
+			t.err = err
+		}
+		return 0, false
+	}
+
+	return b[0], true
+}
+
+func (t *Tokenizer) advance() {
+	b, err := t.reader.ReadByte()
+	if err != nil {
+		return
+	}
+
+	if b == '\n' {
+		t.line++
+		t.column = 1
+		t.current = t.current[:0]
+	} else {
+		t.column++
+		t.current = append(t.current, b)
+	}
+}
+
+// sourceLine is the current line, the rest of the line is peeked so it is not
+// consumed
+func (t *Tokenizer) sourceLine() string {
+	line := string(t.current)
+
+	if _, err := t.reader.Peek(1); err == nil {
+		rest, _ := t.reader.Peek(t.reader.Buffered())
+		if i := bytes.IndexByte(rest, '\n'); i != -1 {
+			rest = rest[:i]
+		}
+		line += string(rest)
+	}
+
+	return strings.TrimSuffix(line, "\r")
+}
+
+func (t *Tokenizer) errorAt(line, column int, format string, args ...any) error {
+	if t.err != nil {
+		return t.err
+	}
+
+	return &Diagnostic{Line: line, Column: column, Source: t.sourceLine(), Err: fmt.Errorf(format, args...)}
+}
+
+// Errorf returns a diagnostic at the current position, for errors found by the
+// caller such as a line with too few fields
+func (t *Tokenizer) Errorf(format string, args ...any) error {
+	return t.errorAt(t.line, t.column, format, args...)
+}
+
+func (t *Tokenizer) found() string {
+	b, ok := t.peek()
+	if !ok {
+		return "the end of the input"
+	}
+
+	return fmt.Sprintf("%q", b)
+}
+
+func isSpace(b byte) bool {
+	return b == ' ' || b == '\t'
+}
+
+func isDigit(b byte) bool {
+	return b >= '0' && b <= '9'
+}
+
+// EOF is true when there is nothing left to read
+func (t *Tokenizer) EOF() bool {
+	_, ok := t.peek()
+	return !ok
+}
+
+// SkipSpaces skips spaces and tabs but not new lines
+func (t *Tokenizer) SkipSpaces() {
+	for {
+		b, ok := t.peek()
+		if !ok || !isSpace(b) {
+			return
+		}
+		t.advance()
+	}
+}
+
+// AcceptNewLine consumes a LF or CRLF if it is next, a CR at the end of the
+// input is also accepted
+func (t *Tokenizer) AcceptNewLine() bool {
+	b, ok := t.peek()
+	if !ok || !t.atNewLine() {
+		return false
+	}
+
+	t.advance()
+	if b == '\r' {
+		t.advance()
+	}
+	return true
+}
+
+func (t *Tokenizer) atNewLine() bool {
+	b, ok := t.peek()
+	if !ok || b == '\n' {
+		return true
+	}
+
+	if b != '\r' {
+		return false
+	}
+
+	next, err := t.reader.Peek(2)
+	if errors.Is(err, io.EOF) {
+		return true
+	}
+
+	return err == nil && next[1] == '\n'
+}
+
+// AtEndOfLine skips spaces and reports whether a new line or the end of the
+// input is next
+func (t *Tokenizer) AtEndOfLine() bool {
+	t.SkipSpaces()
+	return t.atNewLine()
+}
+
+// EndOfLine skips spaces then consumes a new line, the end of the input is also
+// accepted
+func (t *Tokenizer) EndOfLine() error {
+	t.SkipSpaces()
+	if t.EOF() || t.AcceptNewLine() {
+		return t.err
+	}
+
+	return t.Errorf("Expected a new line, found %s", t.found())
+}
+
+// Accept skips spaces then consumes b if it is next
+func (t *Tokenizer) Accept(b byte) bool {
+	t.SkipSpaces()
+
+	next, ok := t.peek()
+	if !ok || next != b {
+		return false
+	}
+
+	t.advance()
+	return true
+}
+
+// Expect skips spaces then consumes b, or returns an error if it is not next
+func (t *Tokenizer) Expect(b byte) error {
+	if t.Accept(b) {
+		return nil
+	}
+
+	return t.Errorf("Expected %q, found %s", b, t.found())
+}
+
+// Int skips spaces then reads a base 10 integer with an optional sign
+func (t *Tokenizer) Int() (int, error) {
+	t.SkipSpaces()
+	line, column := t.Position()
+
+	negative := false
+	if b, ok := t.peek(); ok && (b == '-' || b == '+') {
+		negative = b == '-'
+		t.advance()
+	}
+
+	b, ok := t.peek()
+	if !ok || !isDigit(b) {
+		return 0, t.Errorf("Expected a number, found %s", t.found())
+	}
+
+	number := 0
+	for ok && isDigit(b) {
+		digit := int(b - '0')
+		if number > (math.MaxInt-digit)/10 {
+			return 0, t.errorAt(line, column, "The number is too large")
+		}
+
+		number = number*10 + digit
+		t.advance()
+		b, ok = t.peek()
+	}
+
+	if negative {
+		number = -number
+	}
+
+	return number, nil
+}
+
+// Ints reads the integers up to the end of the line and consumes the new line,
+// a blank line gives an empty slice
+func (t *Tokenizer) Ints() ([]int, error) {
+	ret := make([]int, 0)
+
+	for !t.AtEndOfLine() {
+		number, err := t.Int()
+		if err != nil {
+			return nil, err
+		}
+
+		ret = append(ret, number)
+	}
+
+	t.AcceptNewLine()
+	return ret, t.err
+}
+
+// Field skips spaces then reads bytes up to the next space or new line
+func (t *Tokenizer) Field() (string, error) {
+	t.SkipSpaces()
+
+	field := make([]byte, 0)
+	for !t.atNewLine() {
+		b, _ := t.peek()
+		if isSpace(b) {
+			break
+		}
+
+		field = append(field, b)
+		t.advance()
+	}
+
+	if len(field) == 0 {
+		return "", t.Errorf("Expected a field, found %s", t.found())
+	}
+
+	return string(field), nil
+}
+
+// Line reads the rest of the line without the line ending
+func (t *Tokenizer) Line() ([]byte, error) {
+	line := make([]byte, 0)
+	for !t.atNewLine() {
+		b, _ := t.peek()
+		line = append(line, b)
+		t.advance()
+	}
+
+	t.AcceptNewLine()
+	return line, t.err
+}
@@ -0,0 +1,211 @@
+package tokenizer
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func readAllInts(input string) ([][]int, error) {
+	t := New(strings.NewReader(input))
+	ret := make([][]int, 0)
+
+	for !t.EOF() {
+		line, err := t.Ints()
+		if err != nil {
+			return nil, err
+		}
+		ret = append(ret, line)
+	}
+
+	return ret, t.Err()
+}
+
+func TestInts(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected [][]int
+	}{
+		{name: "leading zero", input: "0     123123\n", expected: [][]int{{0, 123123}}},
+		{name: "signed", input: "-4 +5 -0\n", expected: [][]int{{-4, 5, 0}}},
+		{name: "tabs and spaces", input: " 1\t\t2  \n3\n", expected: [][]int{{1, 2}, {3}}},
+		{name: "crlf", input: "1 2\r\n3 4\r\n", expected: [][]int{{1, 2}, {3, 4}}},
+		{name: "no trailing new line", input: "1 2\n3 4", expected: [][]int{{1, 2}, {3, 4}}},
+		{name: "crlf without the last lf", input: "1 2\r\n3 4\r", expected: [][]int{{1, 2}, {3, 4}}},
+		{name: "blank line", input: "1\n\n2\n", expected: [][]int{{1}, {}, {2}}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			actual, err := readAllInts(test.input)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !slices.EqualFunc(actual, test.expected, slices.Equal) {
+				t.Errorf("Expected %v, got %v", test.expected, actual)
+			}
+		})
+	}
+}
+
+func TestErrorPosition(t *testing.T) {
+	tests := []struct {
+		name         string
+		input        string
+		line, column int
+		source       string
+	}{
+		{name: "bad char", input: "1 2\n3 x 4\n5\n", line: 2, column: 3, source: "3 x 4"},
+		{name: "lone sign", input: "1 -\r\n", line: 1, column: 4, source: "1 -"},
+		{name: "carriage return without new line", input: "1\r2\n", line: 1, column: 2, source: "1\r2"},
+		{name: "too large", input: "1 99999999999999999999999\n", line: 1, column: 3, source: "1 99999999999999999999999"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := readAllInts(test.input)
+
+			var diagnostic *Diagnostic
+			if !errors.As(err, &diagnostic) {
+				t.Fatalf("Expected a diagnostic, got %v", err)
+			}
+
+			if diagnostic.Line != test.line || diagnostic.Column != test.column {
+				t.Errorf("Expected the error at %d:%d, got %d:%d (%s)",
+					test.line, test.column, diagnostic.Line, diagnostic.Column, err)
+			}
+
+			if diagnostic.Source != test.source {
+				t.Errorf("Expected the source line %q, got %q", test.source, diagnostic.Source)
+			}
+		})
+	}
+}
+
+func TestSeparators(t *testing.T) {
+	tokenizer := New(strings.NewReader("47|53\n75,29 , 13\r\n"))
+
+	a, err := tokenizer.Int()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err = tokenizer.Expect('|'); err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := tokenizer.Int()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if a != 47 || b != 53 {
+		t.Errorf("Expected 47|53, got %d|%d", a, b)
+	}
+
+	if err = tokenizer.EndOfLine(); err != nil {
+		t.Fatal(err)
+	}
+
+	sequence := make([]int, 0)
+	for {
+		number, err := tokenizer.Int()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		sequence = append(sequence, number)
+		if !tokenizer.Accept(',') {
+			break
+		}
+	}
+
+	if !slices.Equal(sequence, []int{75, 29, 13}) {
+		t.Errorf("Unexpected sequence %v", sequence)
+	}
+
+	if err = tokenizer.EndOfLine(); err != nil {
+		t.Fatal(err)
+	}
+
+	if !tokenizer.EOF() {
+		t.Error("Expected the end of the input")
+	}
+
+	if err = tokenizer.Expect('|'); err == nil {
+		t.Error("Expected an error at the end of the input")
+	}
+}
+
+func TestFieldAndLine(t *testing.T) {
+	tokenizer := New(strings.NewReader("  ab cd\r\n..#.\r\nlast"))
+
+	for _, expected := range []string{"ab", "cd"} {
+		field, err := tokenizer.Field()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if field != expected {
+			t.Errorf("Expected %q, got %q", expected, field)
+		}
+	}
+
+	if _, err := tokenizer.Field(); err == nil {
+		t.Error("Expected an error at the end of the line")
+	}
+
+	if err := tokenizer.EndOfLine(); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, expected := range []string{"..#.", "last"} {
+		line, err := tokenizer.Line()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if string(line) != expected {
+			t.Errorf("Expected %q, got %q", expected, line)
+		}
+	}
+
+	if !tokenizer.EOF() {
+		t.Error("Expected the end of the input")
+	}
+}
+
+func TestDiagnosticError(t *testing.T) {
+	tests := []struct {
+		name       string
+		diagnostic Diagnostic
+		expected   string
+	}{
+		{
+			name:       "snippet",
+			diagnostic: Diagnostic{File: "input.txt", Line: 12, Column: 3, Source: "4 x", Err: errors.New("Bad")},
+			expected:   "input.txt:12:3: Bad\n 12 | 4 x\n    |   ^",
+		},
+		{
+			name:       "tabs",
+			diagnostic: Diagnostic{Line: 1, Column: 3, Source: "1\tx", Err: errors.New("Bad")},
+			expected:   "1:3: Bad\n 1 | 1\tx\n   |  \t^",
+		},
+		{
+			name:       "empty input",
+			diagnostic: Diagnostic{Line: 1, Column: 1, Err: errors.New("Bad")},
+			expected:   "1:1: Bad",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if actual := test.diagnostic.Error(); actual != test.expected {
+				t.Errorf("Expected %q, got %q", test.expected, actual)
+			}
+		})
+	}
+}
@@ -0,0 +1,20 @@
+package main
+
+import (
+	"github.com/djpiper28/advent-of-code-2024/aoc"
+	"github.com/djpiper28/advent-of-code-2024/day01"
+	"github.com/djpiper28/advent-of-code-2024/day02"
+	"github.com/djpiper28/advent-of-code-2024/day04"
+	"github.com/djpiper28/advent-of-code-2024/day05"
+	"github.com/djpiper28/advent-of-code-2024/day06"
+)
+
+func days() []*aoc.Day {
+	return []*aoc.Day{
+		day01.Day(),
+		day02.Day(),
+		day04.Day(),
+		day05.Day(),
+		day06.Day(),
+	}
+}
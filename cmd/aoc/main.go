@@ -0,0 +1,9 @@
+package main
+
+import (
+	"github.com/djpiper28/advent-of-code-2024/aoc"
+)
+
+func main() {
+	aoc.Dispatch(days())
+}
@@ -1,52 +1,101 @@
-package main
+package day01
 
 import (
+	"bytes"
+	"context"
+	"embed"
+	"encoding/csv"
 	"errors"
+	"fmt"
+	"io"
+	"math"
 	"os"
+	"slices"
 	"sort"
 
 	"github.com/charmbracelet/log"
-	"github.com/jessevdk/go-flags"
-	"golang.org/x/exp/constraints"
+	"github.com/djpiper28/advent-of-code-2024/aoc"
+	"github.com/djpiper28/advent-of-code-2024/aoc/mathx"
+	"github.com/djpiper28/advent-of-code-2024/aoc/tokenizer"
 )
 
 type ParsedData struct {
-	List1, List2 []int
+	// One list per column of the input, sorted by the solver
+	Lists [][]int
+	// The lists in input order, for the rank correlations
+	Unsorted [][]int
+}
+
+const MIN_LISTS = 2
+
+// validate checks that the lists can be paired up, the parser enforces this
+// but the data can be built by other means
+func (d ParsedData) validate() error {
+	if len(d.Lists) < MIN_LISTS {
+		return fmt.Errorf("Expected at least %d lists, found %d", MIN_LISTS, len(d.Lists))
+	}
+
+	for i, list := range d.Lists {
+		if len(list) != len(d.Lists[0]) {
+			return fmt.Errorf("List %d has %d location IDs but list 1 has %d", i+1, len(list), len(d.Lists[0]))
+		}
+	}
+
+	return nil
 }
 
 func parseData(data []byte) (ParsedData, error) {
-	list1 := make([]int, 0)
-	list2 := make([]int, 0)
+	var lists [][]int
+	t := tokenizer.New(bytes.NewReader(data))
+
+	for !t.EOF() {
+		if t.AtEndOfLine() {
+			t.AcceptNewLine()
+			continue
+		}
 
-	isListA := true
-	var temp int
+		row := make([]int, 0, len(lists))
+		for !t.AtEndOfLine() {
+			if lists != nil && len(row) == len(lists) {
+				return ParsedData{}, t.Errorf("Expected %d numbers on the line, found more", len(lists))
+			}
+
+			num, err := t.Int()
+			if err != nil {
+				return ParsedData{}, err
+			}
+
+			row = append(row, num)
+		}
 
-	for _, b := range data {
-		if b == '\n' {
-			if isListA {
-				return ParsedData{}, errors.New("Illegal input - expected another number")
+		if lists == nil {
+			if len(row) < MIN_LISTS {
+				return ParsedData{}, t.Errorf("Expected at least %d numbers on the line, found %d", MIN_LISTS, len(row))
 			}
-			list2 = append(list2, temp)
-			isListA = true
-			temp = 0
-			// Theree is a potential edge case below where data is `0     123123`
-		} else if b == ' ' && temp != 0 {
-			list1 = append(list1, temp)
-			isListA = false
-			temp = 0
-		} else if b >= '0' && b <= '9' {
-			temp *= 10
-			temp += int(b - '0')
+			lists = make([][]int, len(row))
+		} else if len(row) != len(lists) {
+			return ParsedData{}, t.Errorf("Expected %d numbers on the line, found %d", len(lists), len(row))
 		}
+
+		for i, num := range row {
+			lists[i] = append(lists[i], num)
+		}
+
+		t.AcceptNewLine()
+	}
+
+	if err := t.Err(); err != nil {
+		return ParsedData{}, err
 	}
 
-	if len(list2)+1 == len(list1) {
-		list2 = append(list2, temp)
+	length := 0
+	if lists != nil {
+		length = len(lists[0])
 	}
 
-	log.Info("Parsed data", "list1 length", len(list1), "list2 length", len(list2))
+	log.Info("Parsed data", "lists", len(lists), "length", length)
 
-	return ParsedData{List1: list1, List2: list2}, nil
+	return ParsedData{Lists: lists}, nil
 }
 
 type compareFunc = func(i, j int) bool
@@ -57,88 +106,441 @@ func intCompare(data []int) compareFunc {
 	}
 }
 
-func Abs[T constraints.Integer](x T) T {
-	if x < 0 {
-		return -x
+// Matrix holds a value for every ordered pair of lists, it prints as its size
+// and name as the values are shown as a table
+type Matrix[T any] struct {
+	// The metric that the values are for
+	Name   string
+	Values [][]T
+}
+
+func pairwise[T, R any](name string, lists []T, f func(a, b T) R) Matrix[R] {
+	ret := Matrix[R]{Name: name, Values: make([][]R, len(lists))}
+	for i, a := range lists {
+		ret.Values[i] = make([]R, len(lists))
+		for j, b := range lists {
+			ret.Values[i][j] = f(a, b)
+		}
+	}
+
+	return ret
+}
+
+// Answer is the value for the first and second list when there are only two
+// lists, otherwise it is the whole matrix
+func (m Matrix[T]) Answer() any {
+	if len(m.Values) == MIN_LISTS {
+		return m.Values[0][1]
+	}
+
+	return m
+}
+
+func (m Matrix[T]) String() string {
+	return fmt.Sprintf("%dx%d %s matrix", len(m.Values), len(m.Values), m.Name)
+}
+
+func (m Matrix[T]) Header() []string {
+	ret := []string{""}
+	for i := range m.Values {
+		ret = append(ret, fmt.Sprintf("list %d", i+1))
+	}
+
+	return ret
+}
+
+func (m Matrix[T]) Rows() [][]string {
+	ret := make([][]string, 0, len(m.Values))
+	for i, values := range m.Values {
+		row := []string{fmt.Sprintf("list %d", i+1)}
+		for _, value := range values {
+			row = append(row, fmt.Sprint(value))
+		}
+		ret = append(ret, row)
 	}
-	return x
+
+	return ret
 }
 
-func part1Calculation(data ParsedData) {
+// distance pairs up the sorted lists and sums the differences
+func distance(a, b []int) int {
 	sum := 0
 
-	for i, a := range data.List1 {
-		b := data.List2[i]
+	for i, x := range a {
+		y := b[i]
 
-		difference := Abs(a - b)
+		difference := mathx.Abs(x - y)
 		sum += difference
 	}
 
-	log.Info("Complete!", "output", sum)
+	return sum
 }
 
-func part2Calculation(data ParsedData) {
-	countMap := make(map[int]int)
+func squaredDistance(a, b []int) int {
+	sum := 0
+
+	for i, x := range a {
+		difference := x - b[i]
+		sum += difference * difference
+	}
+
+	return sum
+}
+
+func maxDistance(a, b []int) int {
+	ret := 0
+
+	for i, x := range a {
+		ret = max(ret, mathx.Abs(x-b[i]))
+	}
+
+	return ret
+}
+
+func mismatches(a, b []int) int {
+	count := 0
+
+	for i, x := range a {
+		if x != b[i] {
+			count++
+		}
+	}
+
+	return count
+}
+
+// ranks gives each number its position in the sorted list starting at 1, tied
+// numbers share the mean of their positions
+func ranks(data []int) []float64 {
+	order := make([]int, len(data))
+	for i := range order {
+		order[i] = i
+	}
+
+	sort.Slice(order, func(i, j int) bool {
+		return data[order[i]] < data[order[j]]
+	})
+
+	ret := make([]float64, len(data))
+	for i := 0; i < len(order); {
+		j := i
+		for j < len(order) && data[order[j]] == data[order[i]] {
+			j++
+		}
+
+		rank := float64(i+j+1) / 2
+		for _, index := range order[i:j] {
+			ret[index] = rank
+		}
+		i = j
+	}
+
+	return ret
+}
+
+// spearman is the Pearson correlation of the ranks of the lists
+func spearman(a, b []int) float64 {
+	rankA, rankB := ranks(a), ranks(b)
+	mean := float64(len(a)+1) / 2
+
+	var covariance, varianceA, varianceB float64
+	for i := range rankA {
+		x, y := rankA[i]-mean, rankB[i]-mean
+		covariance += x * y
+		varianceA += x * x
+		varianceB += y * y
+	}
+
+	return covariance / math.Sqrt(varianceA*varianceB)
+}
 
-	countArray := func(data []int) {
-		for _, num := range data {
-			count, found := countMap[num]
-			if !found {
-				countMap[num] = 1
-			} else {
-				countMap[num] = count + 1
+// kendall is the tau-b rank correlation, which accounts for ties
+func kendall(a, b []int) float64 {
+	var concordance, tiesA, tiesB, pairs int
+	for i := range a {
+		for j := i + 1; j < len(a); j++ {
+			x, y := mathx.Sign(a[i]-a[j]), mathx.Sign(b[i]-b[j])
+			concordance += x * y
+			if x == 0 {
+				tiesA++
+			}
+			if y == 0 {
+				tiesB++
 			}
+			pairs++
 		}
 	}
 
-	countArray(data.List2)
+	return float64(concordance) / math.Sqrt(float64(pairs-tiesA)*float64(pairs-tiesB))
+}
+
+const (
+	METRIC_L1       = "l1"
+	METRIC_L2       = "l2"
+	METRIC_MAX      = "max"
+	METRIC_MISMATCH = "mismatch"
+	METRIC_SPEARMAN = "spearman"
+	METRIC_KENDALL  = "kendall"
+)
+
+// Distances between the sorted lists
+var DISTANCES = map[string]func(a, b []int) int{
+	METRIC_L1:       distance,
+	METRIC_L2:       squaredDistance,
+	METRIC_MAX:      maxDistance,
+	METRIC_MISMATCH: mismatches,
+}
+
+// Correlations between the lists in input order
+var CORRELATIONS = map[string]func(a, b []int) float64{
+	METRIC_SPEARMAN: spearman,
+	METRIC_KENDALL:  kendall,
+}
+
+type Options struct {
+	Metric string `long:"metric" choice:"l1" choice:"l2" choice:"max" choice:"mismatch" choice:"spearman" choice:"kendall" default-mask:"l1" description:"How part 1 compares the lists, spearman and kendall are rank correlations of the lists in input order"`
+
+	Explain    bool   `long:"explain" description:"Show how much each location ID in list 1 contributes to the part 2 similarity with list 2, there must be 2 lists"`
+	ExplainCsv string `long:"explain-csv" value-name:"FILE" description:"Write the part 2 breakdown to a csv file"`
+}
+
+func countList(data []int) map[int]int {
+	countMap := make(map[int]int)
+	for _, num := range data {
+		countMap[num]++
+	}
+
+	return countMap
+}
 
+// similarity sums each number in a multiplied by how many times it is in b
+func similarity(a []int, countMap map[int]int) int {
 	sum := 0
 
-	for _, num := range data.List1 {
+	for _, num := range a {
 		count, _ := countMap[num]
 		difference := num * count
 		sum += difference
 	}
 
-	log.Info("Complete!", "output", sum)
+	return sum
+}
+
+// answer is a matrix of any type of value
+type answer interface {
+	aoc.Table
+	Answer() any
+}
+
+// BreakdownRow is how a distinct location ID from the left list contributes to
+// the similarity score
+type BreakdownRow struct {
+	Id           int
+	Left         int
+	Right        int
+	Contribution int
+}
+
+// Breakdown is sorted with the biggest contributions first, it prints as the
+// total so that it can be used as the answer
+type Breakdown []BreakdownRow
+
+func breakdown(a []int, countMap map[int]int) Breakdown {
+	ret := make(Breakdown, 0)
+	for id, left := range countList(a) {
+		right := countMap[id]
+		ret = append(ret, BreakdownRow{
+			Id:           id,
+			Left:         left,
+			Right:        right,
+			Contribution: id * left * right,
+		})
+	}
+
+	sort.Slice(ret, func(i, j int) bool {
+		if ret[i].Contribution != ret[j].Contribution {
+			return ret[i].Contribution > ret[j].Contribution
+		}
+		return ret[i].Id < ret[j].Id
+	})
+
+	return ret
 }
 
-func main() {
-	var opts struct {
-		Part2 bool `short:"p" long:"part" description:"Whether to calculate for part 2"`
+func (b Breakdown) Total() int {
+	sum := 0
+	for _, row := range b {
+		sum += row.Contribution
 	}
 
-	_, err := flags.Parse(&opts)
-	if err != nil {
-		log.Fatal("Cannot parse cli args", "err", err)
+	return sum
+}
+
+func (b Breakdown) String() string {
+	return fmt.Sprint(b.Total())
+}
+
+func (b Breakdown) Header() []string {
+	return []string{"id", "left", "right", "contribution"}
+}
+
+func (b Breakdown) Rows() [][]string {
+	ret := make([][]string, 0, len(b))
+	for _, row := range b {
+		ret = append(ret, []string{
+			fmt.Sprint(row.Id),
+			fmt.Sprint(row.Left),
+			fmt.Sprint(row.Right),
+			fmt.Sprint(row.Contribution),
+		})
+	}
+
+	return ret
+}
+
+func (b Breakdown) WriteCsv(w io.Writer) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(b.Header()); err != nil {
+		return err
 	}
 
-	if opts.Part2 {
-		log.Info("Part 2 of the problem")
-	} else {
-		log.Info("Part 1 of the problem")
+	if err := writer.WriteAll(b.Rows()); err != nil {
+		return err
 	}
 
-	log.Info("Reading data....")
-	bytes, err := os.ReadFile("input.txt")
+	return writer.Error()
+}
+
+func writeBreakdown(path string, b Breakdown) error {
+	file, err := os.Create(path)
 	if err != nil {
-		log.Fatal("Cannot read the data from the file")
+		return fmt.Errorf("Cannot create the breakdown file: %w", err)
+	}
+
+	if err = b.WriteCsv(file); err != nil {
+		file.Close()
+		return fmt.Errorf("Cannot write the breakdown: %w", err)
+	}
+
+	return file.Close()
+}
+
+func part1Calculation(data ParsedData, metric string) (answer, error) {
+	if err := data.validate(); err != nil {
+		return nil, err
+	}
+
+	if f, ok := DISTANCES[metric]; ok {
+		return pairwise(metric, data.Lists, f), nil
 	}
 
-	log.Info("Parsing data...")
-	data, err := parseData(bytes)
+	f, ok := CORRELATIONS[metric]
+	if !ok {
+		return nil, fmt.Errorf("Unknown metric %q", metric)
+	}
+
+	// The correlation is undefined when every number in a list is the same
+	for i, list := range data.Unsorted {
+		if len(countList(list)) < 2 {
+			return nil, fmt.Errorf("Cannot use the %s metric as list %d has fewer than 2 distinct location IDs", metric, i+1)
+		}
+	}
+
+	return pairwise(metric, data.Unsorted, f), nil
+}
+
+// The name of the part 2 matrix
+const SIMILARITY = "similarity"
+
+type countedList struct {
+	list     []int
+	countMap map[int]int
+}
+
+func part2Calculation(data ParsedData) Matrix[int] {
+	counted := make([]countedList, len(data.Lists))
+	for i, list := range data.Lists {
+		counted[i] = countedList{list: list, countMap: countList(list)}
+	}
+
+	return pairwise(SIMILARITY, counted, func(a, b countedList) int {
+		return similarity(a.list, b.countMap)
+	})
+}
+
+type solver struct {
+	opts *Options
+}
+
+func (solver) Parse(input []byte) (ParsedData, error) {
+	data, err := parseData(input)
 	if err != nil {
-		log.Fatal("Cannot parse data", "err", err)
+		return data, err
+	}
+
+	if len(data.Lists) == 0 {
+		return data, errors.New("There are no location IDs")
+	}
+
+	if err = data.validate(); err != nil {
+		return data, err
 	}
 
 	log.Info("Sorting lists...")
-	sort.Slice(data.List1, intCompare(data.List1))
-	sort.Slice(data.List2, intCompare(data.List2))
+	data.Unsorted = make([][]int, len(data.Lists))
+	for i, list := range data.Lists {
+		data.Unsorted[i] = slices.Clone(list)
+		sort.Slice(list, intCompare(list))
+	}
+	return data, nil
+}
+
+func (s solver) Part1(_ context.Context, data ParsedData) (any, error) {
+	log.Info("Comparing lists", "metric", s.opts.Metric)
+	matrix, err := part1Calculation(data, s.opts.Metric)
+	if err != nil {
+		return nil, err
+	}
+
+	return matrix.Answer(), nil
+}
+
+func (s solver) Part2(_ context.Context, data ParsedData) (any, error) {
+	if !s.opts.Explain && s.opts.ExplainCsv == "" {
+		return part2Calculation(data).Answer(), nil
+	}
+
+	// The breakdown is only the answer when it is the same as the total
+	if len(data.Lists) != MIN_LISTS {
+		return nil, fmt.Errorf("Cannot explain the similarity of %d lists, expected %d", len(data.Lists), MIN_LISTS)
+	}
+
+	if err := data.validate(); err != nil {
+		return nil, err
+	}
+
+	b := breakdown(data.Lists[0], countList(data.Lists[1]))
+	if s.opts.ExplainCsv != "" {
+		log.Info("Writing the breakdown", "file", s.opts.ExplainCsv, "ids", len(b))
+		if err := writeBreakdown(s.opts.ExplainCsv, b); err != nil {
+			return nil, err
+		}
+	}
 
-	if opts.Part2 {
-		part2Calculation(data)
-	} else {
-		part1Calculation(data)
+	if !s.opts.Explain {
+		return b.Total(), nil
 	}
+
+	return b, nil
+}
+
+//go:embed examples
+var examples embed.FS
+
+func Day() *aoc.Day {
+	opts := &Options{Metric: METRIC_L1}
+	day := aoc.NewDay("day01", solver{opts: opts})
+	day.Examples = examples
+	day.SetOptions(opts)
+	return day
 }
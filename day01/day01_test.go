@@ -0,0 +1,339 @@
+package day01
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/djpiper28/advent-of-code-2024/aoc/aoctest"
+)
+
+func TestExample(t *testing.T) {
+	input, err := os.ReadFile("examples/example.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := solver{}.Parse(input)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		part     func(context.Context, ParsedData) (any, error)
+		expected any
+	}{
+		{name: "part 1", part: solver{opts: &Options{Metric: METRIC_L1}}.Part1, expected: 11},
+		{name: "part 2", part: solver{opts: &Options{}}.Part2, expected: 31},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			answer, err := test.part(context.Background(), data)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if answer != test.expected {
+				t.Errorf("Expected %v, got %v", test.expected, answer)
+			}
+		})
+	}
+}
+
+func TestParseData(t *testing.T) {
+	tests := []struct {
+		name          string
+		input         string
+		lists         [][]int
+		expectedError bool
+	}{
+		{name: "trailing new line", input: "3   4\n4   3\n", lists: [][]int{{3, 4}, {4, 3}}},
+		{name: "no trailing new line", input: "3   4\n4   3", lists: [][]int{{3, 4}, {4, 3}}},
+		{name: "multiple digits", input: "123   4567\n", lists: [][]int{{123}, {4567}}},
+		{name: "leading zero", input: "0     123123\n", lists: [][]int{{0}, {123123}}},
+		{name: "crlf and tabs", input: "3\t4\r\n4 3\r\n", lists: [][]int{{3, 4}, {4, 3}}},
+		{name: "zero first", input: "0 5\n0 0\n", lists: [][]int{{0, 0}, {5, 0}}},
+		{name: "negative", input: "-3   4\n+4   -0\n", lists: [][]int{{-3, 4}, {4, 0}}},
+		{name: "three columns", input: "1 2 3\n4 5 6\n", lists: [][]int{{1, 4}, {2, 5}, {3, 6}}},
+		{name: "missing second number", input: "3\n", expectedError: true},
+		{name: "extra number", input: "3 4\n3 4 5\n", expectedError: true},
+		{name: "missing number", input: "3 4 5\n3 4\n", expectedError: true},
+		{name: "lone minus", input: "- 4\n", expectedError: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			data, err := parseData([]byte(test.input))
+			if test.expectedError {
+				if err == nil {
+					t.Fatal("Expected an error")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !slices.EqualFunc(data.Lists, test.lists, slices.Equal) {
+				t.Errorf("Expected %v, got %v", test.lists, data.Lists)
+			}
+		})
+	}
+}
+
+func TestPart2CountsMissingAsZero(t *testing.T) {
+	data := ParsedData{Lists: [][]int{{1, 2, 3}, {3, 3, 4}}}
+
+	if actual := part2Calculation(data).Answer(); actual != 6 {
+		t.Errorf("Expected 6, got %v", actual)
+	}
+}
+
+func TestPart1DifferentLengths(t *testing.T) {
+	data := ParsedData{Lists: [][]int{{1, 2, 3}, {3, 4}}}
+
+	if _, err := part1Calculation(data, METRIC_L1); err == nil {
+		t.Fatal("Expected an error")
+	}
+}
+
+func TestMetrics(t *testing.T) {
+	input, err := os.ReadFile("examples/example.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := solver{}.Parse(input)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		metric   string
+		expected float64
+	}{
+		{metric: METRIC_L1, expected: 11},
+		{metric: METRIC_L2, expected: 35},
+		{metric: METRIC_MAX, expected: 5},
+		{metric: METRIC_MISMATCH, expected: 5},
+		{metric: METRIC_SPEARMAN, expected: -0.0967741935483871},
+		{metric: METRIC_KENDALL, expected: -0.08333333333333333},
+	}
+
+	for _, test := range tests {
+		t.Run(test.metric, func(t *testing.T) {
+			matrix, err := part1Calculation(data, test.metric)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var actual float64
+			switch answer := matrix.Answer().(type) {
+			case int:
+				actual = float64(answer)
+			case float64:
+				actual = answer
+			default:
+				t.Fatalf("Unexpected answer %v", answer)
+			}
+
+			if math.Abs(actual-test.expected) > 1e-9 {
+				t.Errorf("Expected %v, got %v", test.expected, actual)
+			}
+		})
+	}
+}
+
+func TestCorrelationOfConstantList(t *testing.T) {
+	data := ParsedData{Lists: [][]int{{1, 1}, {1, 2}}, Unsorted: [][]int{{1, 1}, {2, 1}}}
+
+	if _, err := part1Calculation(data, METRIC_SPEARMAN); err == nil {
+		t.Fatal("Expected an error")
+	}
+}
+
+func TestUnknownMetric(t *testing.T) {
+	data := ParsedData{Lists: [][]int{{1, 2}, {1, 2}}, Unsorted: [][]int{{1, 2}, {1, 2}}}
+
+	if _, err := part1Calculation(data, "l3"); err == nil {
+		t.Fatal("Expected an error")
+	}
+}
+
+func TestBreakdown(t *testing.T) {
+	data := ParsedData{Lists: [][]int{{3, 4, 2, 1, 3, 3}, {4, 3, 5, 3, 9, 3}}}
+
+	actual := breakdown(data.Lists[0], countList(data.Lists[1]))
+	expected := Breakdown{
+		{Id: 3, Left: 3, Right: 3, Contribution: 27},
+		{Id: 4, Left: 1, Right: 1, Contribution: 4},
+		{Id: 1, Left: 1, Right: 0, Contribution: 0},
+		{Id: 2, Left: 1, Right: 0, Contribution: 0},
+	}
+
+	if !slices.Equal(actual, expected) {
+		t.Errorf("Expected %v, got %v", expected, actual)
+	}
+
+	if total := actual.Total(); total != 31 {
+		t.Errorf("Expected a total of 31, got %d", total)
+	}
+
+	var buffer bytes.Buffer
+	if err := actual.WriteCsv(&buffer); err != nil {
+		t.Fatal(err)
+	}
+
+	expectedCsv := "id,left,right,contribution\n3,3,3,27\n4,1,1,4\n1,1,0,0\n2,1,0,0\n"
+	if buffer.String() != expectedCsv {
+		t.Errorf("Expected %q, got %q", expectedCsv, buffer.String())
+	}
+}
+
+func TestExplain(t *testing.T) {
+	two := ParsedData{Lists: [][]int{{1, 2, 3, 3, 3, 4}, {3, 3, 3, 4, 5, 9}}}
+	three := ParsedData{Lists: [][]int{{1, 2}, {2, 3}, {3, 4}}}
+
+	tests := []struct {
+		name          string
+		opts          Options
+		data          ParsedData
+		expected      string
+		expectedError bool
+	}{
+		{name: "explain", opts: Options{Explain: true}, data: two, expected: "31"},
+		{name: "csv", opts: Options{ExplainCsv: filepath.Join(t.TempDir(), "breakdown.csv")}, data: two, expected: "31"},
+		{name: "explain three lists", opts: Options{Explain: true}, data: three, expectedError: true},
+		{name: "csv three lists", opts: Options{ExplainCsv: filepath.Join(t.TempDir(), "breakdown.csv")}, data: three, expectedError: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			answer, err := solver{opts: &test.opts}.Part2(context.Background(), test.data)
+			if test.expectedError {
+				if err == nil {
+					t.Fatal("Expected an error")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if actual := fmt.Sprint(answer); actual != test.expected {
+				t.Errorf("Expected %s, got %s", test.expected, actual)
+			}
+
+			if test.opts.ExplainCsv == "" {
+				return
+			}
+
+			csv, err := os.ReadFile(test.opts.ExplainCsv)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !bytes.HasPrefix(csv, []byte("id,left,right,contribution\n3,3,3,27\n")) {
+				t.Errorf("Unexpected breakdown %q", csv)
+			}
+		})
+	}
+}
+
+func TestThreeColumns(t *testing.T) {
+	input, err := os.ReadFile("examples/three-columns.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := solver{}.Parse(input)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		part     func(ParsedData) (answer, error)
+		expected Matrix[int]
+	}{
+		{name: "part 1", part: func(data ParsedData) (answer, error) { return part1Calculation(data, METRIC_L1) }, expected: Matrix[int]{Name: METRIC_L1, Values: [][]int{{0, 11, 9}, {11, 0, 4}, {9, 4, 0}}}},
+		{name: "part 2", part: func(data ParsedData) (answer, error) { return part2Calculation(data), nil }, expected: Matrix[int]{Name: SIMILARITY, Values: [][]int{{34, 31, 15}, {31, 45, 27}, {15, 27, 25}}}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			result, err := test.part(data)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			actual, ok := result.(Matrix[int])
+			if !ok {
+				t.Fatalf("Expected a matrix of ints, got %T", result)
+			}
+
+			if actual.Name != test.expected.Name || !slices.EqualFunc(actual.Values, test.expected.Values, slices.Equal) {
+				t.Errorf("Expected %s %v, got %s %v", test.expected.Name, test.expected.Values, actual.Name, actual.Values)
+			}
+
+			if expected := "3x3 " + test.expected.Name + " matrix"; fmt.Sprint(actual.Answer()) != expected {
+				t.Errorf("Expected the answer %q, got %q", expected, fmt.Sprint(actual.Answer()))
+			}
+		})
+	}
+}
+
+func formatData(data ParsedData) []byte {
+	var buffer bytes.Buffer
+	for i := range data.Lists[0] {
+		for j, list := range data.Lists {
+			if j > 0 {
+				buffer.WriteString("   ")
+			}
+			fmt.Fprintf(&buffer, "%d", list[i])
+		}
+		buffer.WriteByte('\n')
+	}
+
+	return buffer.Bytes()
+}
+
+func FuzzParseData(f *testing.F) {
+	aoctest.Quiet(f)
+
+	f.Fuzz(func(t *testing.T, input []byte) {
+		data, err := parseData(input)
+		if err != nil || len(data.Lists) == 0 {
+			return
+		}
+
+		for _, list := range data.Lists {
+			if len(list) != len(data.Lists[0]) {
+				t.Fatalf("The lists have different lengths %d and %d", len(data.Lists[0]), len(list))
+			}
+		}
+
+		formatted := formatData(data)
+		reparsed, err := parseData(formatted)
+		if err != nil {
+			t.Fatalf("Cannot parse the formatted data %q: %s", formatted, err)
+		}
+
+		if !slices.EqualFunc(data.Lists, reparsed.Lists, slices.Equal) {
+			t.Errorf("Expected %v after a round trip, got %v", data.Lists, reparsed.Lists)
+		}
+	})
+}
+
+func BenchmarkDay(b *testing.B) {
+	aoctest.Benchmark(b, Day())
+}
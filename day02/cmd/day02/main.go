@@ -0,0 +1,10 @@
+package main
+
+import (
+	"github.com/djpiper28/advent-of-code-2024/aoc"
+	"github.com/djpiper28/advent-of-code-2024/day02"
+)
+
+func main() {
+	aoc.Main(day02.Day())
+}
@@ -1,58 +1,38 @@
-package main
+package day02
 
 import (
+	"bytes"
+	"context"
+	"embed"
 	"errors"
-	"fmt"
-	"os"
 
 	"github.com/charmbracelet/log"
-	"github.com/jessevdk/go-flags"
-	"golang.org/x/exp/constraints"
+	"github.com/djpiper28/advent-of-code-2024/aoc"
+	"github.com/djpiper28/advent-of-code-2024/aoc/mathx"
+	"github.com/djpiper28/advent-of-code-2024/aoc/tokenizer"
 )
 
 type ParsedData struct {
 	Data []int
 }
 
-const UNSET = -1
-
 func parseData(data []byte) ([]ParsedData, error) {
 	parsedLines := make([]ParsedData, 0)
-	currentLine := make([]int, 0)
-	currentNum := UNSET
+	t := tokenizer.New(bytes.NewReader(data))
 
-	appendCurrentNum := func() {
-		if currentNum != UNSET {
-			currentLine = append(currentLine, currentNum)
-			currentNum = UNSET
+	for !t.EOF() {
+		line, err := t.Ints()
+		if err != nil {
+			return nil, err
 		}
-	}
 
-	appendCurrentLine := func() {
-		appendCurrentNum()
-		parsedLines = append(parsedLines, ParsedData{Data: currentLine})
-		currentLine = make([]int, 0)
-	}
-
-	for _, b := range data {
-		if b >= '0' && b <= '9' {
-			if currentNum == UNSET {
-				currentNum = 0
-			}
-
-			currentNum *= 10
-			currentNum += int(b - '0')
-		} else if b == ' ' {
-			appendCurrentNum()
-		} else if b == '\n' {
-			appendCurrentLine()
-		} else {
-			return nil, fmt.Errorf("Cannot parse %c - unrecognised char", b)
+		if len(line) > 0 {
+			parsedLines = append(parsedLines, ParsedData{Data: line})
 		}
 	}
 
-	if currentNum != UNSET {
-		appendCurrentLine()
+	if err := t.Err(); err != nil {
+		return nil, err
 	}
 
 	log.Info("Finished parsing data", "len", len(parsedLines))
@@ -60,13 +40,6 @@ func parseData(data []byte) ([]ParsedData, error) {
 	return parsedLines, nil
 }
 
-func Abs[T constraints.Integer](x T) T {
-	if x < 0 {
-		return -x
-	}
-	return x
-}
-
 func validateLineIsSafe(input ParsedData) error {
 	isUp := true
 
@@ -86,7 +59,7 @@ func validateLineIsSafe(input ParsedData) error {
 					return errors.New("Differing isUp values are 'unsafe'")
 				}
 
-				if Abs(difference) > 3 {
+				if mathx.Abs(difference) > 3 {
 					return errors.New("Large differences (>3) are 'unsafe'")
 				}
 			}
@@ -147,40 +120,25 @@ func calculatePart2(data []ParsedData) int {
 	return sum
 }
 
-func main() {
-	var opts struct {
-		Part2 bool `short:"p" long:"part" description:"Whether to calculate for part 2"`
-	}
+type solver struct{}
 
-	_, err := flags.Parse(&opts)
-	if err != nil {
-		log.Fatal("Cannot parse cli args", "err", err)
-	}
+func (solver) Parse(input []byte) ([]ParsedData, error) {
+	return parseData(input)
+}
 
-	if opts.Part2 {
-		log.Info("Part 2 of the problem")
-	} else {
-		log.Info("Part 1 of the problem")
-	}
+func (solver) Part1(_ context.Context, data []ParsedData) (any, error) {
+	return calculatePart1(data), nil
+}
 
-	log.Info("Reading file...")
-	bytes, err := os.ReadFile("input.txt")
-	if err != nil {
-		log.Fatal("Cannot read file", "err", err)
-	}
+func (solver) Part2(_ context.Context, data []ParsedData) (any, error) {
+	return calculatePart2(data), nil
+}
 
-	log.Info("Parsing data...")
-	data, err := parseData(bytes)
-	if err != nil {
-		log.Fatal("Cannot parse data", "err", err)
-	}
+//go:embed examples
+var examples embed.FS
 
-	log.Info("Processing data...")
-	if opts.Part2 {
-		output := calculatePart2(data)
-		log.Info("Processing done", "output", output)
-	} else {
-		output := calculatePart1(data)
-		log.Info("Processing done", "output", output)
-	}
+func Day() *aoc.Day {
+	day := aoc.NewDay("day02", solver{})
+	day.Examples = examples
+	return day
 }
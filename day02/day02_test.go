@@ -0,0 +1,178 @@
+package day02
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"slices"
+	"testing"
+
+	"github.com/djpiper28/advent-of-code-2024/aoc/aoctest"
+)
+
+func TestExample(t *testing.T) {
+	input, err := os.ReadFile("examples/example.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := solver{}.Parse(input)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		part     func(context.Context, []ParsedData) (any, error)
+		expected any
+	}{
+		{name: "part 1", part: solver{}.Part1, expected: 2},
+		{name: "part 2", part: solver{}.Part2, expected: 4},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			answer, err := test.part(context.Background(), data)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if answer != test.expected {
+				t.Errorf("Expected %v, got %v", test.expected, answer)
+			}
+		})
+	}
+}
+
+func TestParseData(t *testing.T) {
+	tests := []struct {
+		name          string
+		input         string
+		expected      [][]int
+		expectedError bool
+	}{
+		{name: "single line", input: "1 2 3\n", expected: [][]int{{1, 2, 3}}},
+		{name: "no trailing new line", input: "1 2\n3 4", expected: [][]int{{1, 2}, {3, 4}}},
+		{name: "zeros", input: "0 10 0\n", expected: [][]int{{0, 10, 0}}},
+		{name: "crlf and blank lines", input: "1 2\r\n\r\n3\t4\r\n", expected: [][]int{{1, 2}, {3, 4}}},
+		{name: "unrecognised char", input: "1 a\n", expectedError: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			data, err := parseData([]byte(test.input))
+			if test.expectedError {
+				if err == nil {
+					t.Fatal("Expected an error")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if len(data) != len(test.expected) {
+				t.Fatalf("Expected %d lines, got %d", len(test.expected), len(data))
+			}
+
+			for i, line := range data {
+				if !slices.Equal(line.Data, test.expected[i]) {
+					t.Errorf("Line %d expected %v, got %v", i, test.expected[i], line.Data)
+				}
+			}
+		})
+	}
+}
+
+func TestValidateLineIsSafe(t *testing.T) {
+	tests := []struct {
+		name string
+		line []int
+		safe bool
+	}{
+		{name: "decreasing", line: []int{7, 6, 4, 2, 1}, safe: true},
+		{name: "increasing", line: []int{1, 3, 6, 7, 9}, safe: true},
+		{name: "large increase", line: []int{1, 2, 7, 8, 9}, safe: false},
+		{name: "large decrease", line: []int{9, 7, 6, 2, 1}, safe: false},
+		{name: "changes direction", line: []int{1, 3, 2, 4, 5}, safe: false},
+		{name: "equal values", line: []int{8, 6, 4, 4, 1}, safe: false},
+		{name: "single value", line: []int{1}, safe: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := validateLineIsSafe(ParsedData{Data: test.line})
+			if (err == nil) != test.safe {
+				t.Errorf("Expected safe=%t, got err=%v", test.safe, err)
+			}
+		})
+	}
+}
+
+func TestCalculateLine2(t *testing.T) {
+	tests := []struct {
+		name string
+		line []int
+		safe bool
+	}{
+		{name: "already safe", line: []int{7, 6, 4, 2, 1}, safe: true},
+		{name: "remove middle", line: []int{1, 3, 2, 4, 5}, safe: true},
+		{name: "remove first", line: []int{9, 1, 2, 3}, safe: true},
+		{name: "remove last", line: []int{1, 2, 3, 9}, safe: true},
+		{name: "cannot fix", line: []int{1, 2, 7, 8, 9}, safe: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := calculateLine2(ParsedData{Data: test.line})
+			if (err == nil) != test.safe {
+				t.Errorf("Expected safe=%t, got err=%v", test.safe, err)
+			}
+		})
+	}
+}
+
+func formatData(data []ParsedData) []byte {
+	var buffer bytes.Buffer
+	for _, line := range data {
+		for i, num := range line.Data {
+			if i > 0 {
+				buffer.WriteByte(' ')
+			}
+			fmt.Fprint(&buffer, num)
+		}
+		buffer.WriteByte('\n')
+	}
+
+	return buffer.Bytes()
+}
+
+func FuzzParseData(f *testing.F) {
+	aoctest.Quiet(f)
+
+	f.Fuzz(func(t *testing.T, input []byte) {
+		data, err := parseData(input)
+		if err != nil {
+			return
+		}
+
+		formatted := formatData(data)
+		reparsed, err := parseData(formatted)
+		if err != nil {
+			t.Fatalf("Cannot parse the formatted data %q: %s", formatted, err)
+		}
+
+		equal := slices.EqualFunc(data, reparsed, func(a, b ParsedData) bool {
+			return slices.Equal(a.Data, b.Data)
+		})
+		if !equal {
+			t.Errorf("Expected %v after a round trip, got %v", data, reparsed)
+		}
+	})
+}
+
+func BenchmarkDay(b *testing.B) {
+	aoctest.Benchmark(b, Day())
+}
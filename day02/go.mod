@@ -1,20 +0,0 @@
-module github.com/djpiper28/advent-of-code-2024/day02
-
-go 1.22.6
-
-require github.com/charmbracelet/log v0.4.0
-
-require (
-	github.com/aymanbagabas/go-osc52/v2 v2.0.1 // indirect
-	github.com/charmbracelet/lipgloss v0.10.0 // indirect
-	github.com/go-logfmt/logfmt v0.6.0 // indirect
-	github.com/jessevdk/go-flags v1.6.1 // indirect
-	github.com/lucasb-eyer/go-colorful v1.2.0 // indirect
-	github.com/mattn/go-isatty v0.0.18 // indirect
-	github.com/mattn/go-runewidth v0.0.15 // indirect
-	github.com/muesli/reflow v0.3.0 // indirect
-	github.com/muesli/termenv v0.15.2 // indirect
-	github.com/rivo/uniseg v0.4.7 // indirect
-	golang.org/x/exp v0.0.0-20241108190413-2d47ceb2692f // indirect
-	golang.org/x/sys v0.21.0 // indirect
-)
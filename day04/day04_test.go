@@ -0,0 +1,189 @@
+package day04
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"slices"
+	"testing"
+
+	"github.com/djpiper28/advent-of-code-2024/aoc/aoctest"
+	"github.com/djpiper28/advent-of-code-2024/aoc/grid"
+)
+
+func TestExample(t *testing.T) {
+	input, err := os.ReadFile("examples/example.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := solver{}.Parse(input)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		part     func(context.Context, ParsedData) (any, error)
+		expected any
+	}{
+		{name: "part 1", part: solver{}.Part1, expected: 18},
+		{name: "part 2", part: solver{}.Part2, expected: 9},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			answer, err := test.part(context.Background(), data)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if answer != test.expected {
+				t.Errorf("Expected %v, got %v", test.expected, answer)
+			}
+		})
+	}
+}
+
+func TestParseData(t *testing.T) {
+	data, err := parseData([]byte("XMAS\nSAMX\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if data.letters.Width() != 4 || data.letters.Height() != 2 {
+		t.Errorf("Expected a 4x2 grid, got %dx%d", data.letters.Width(), data.letters.Height())
+	}
+
+	if data.letters.At(grid.Vec2{X: 0, Y: 0}) != 'X' || data.letters.At(grid.Vec2{X: 3, Y: 1}) != 'X' {
+		t.Errorf("Unexpected letters %q", data.letters.Format(func(b byte) string { return string(b) }))
+	}
+
+	if _, err = parseData([]byte("XMAS\nSAM\n")); err == nil {
+		t.Error("Expected an error for rows of different lengths")
+	}
+}
+
+func TestScanners(t *testing.T) {
+	tests := []struct {
+		name string
+		grid string
+	}{
+		{name: "LtR", grid: "XMAS\n....\n....\n....\n"},
+		{name: "RtL", grid: "SAMX\n....\n....\n....\n"},
+		{name: "TtB", grid: ".X..\n.M..\n.A..\n.S..\n"},
+		{name: "BtT", grid: "..S.\n..A.\n..M.\n..X.\n"},
+		{name: "TLtBR", grid: "X...\n.M..\n..A.\n...S\n"},
+		{name: "BRtTL", grid: "S...\n.A..\n..M.\n...X\n"},
+		{name: "TRtBL", grid: "...X\n..M.\n.A..\nS...\n"},
+		{name: "BLtTR", grid: "...S\n..A.\n.M..\nX...\n"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			data, err := parseData([]byte(test.grid))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			i := slices.IndexFunc(SCAN_DIRECTIONS, func(scan scanDirection) bool { return scan.name == test.name })
+			if i == -1 {
+				t.Fatalf("There is no %s scan direction", test.name)
+			}
+
+			if actual := data.scan(SCAN_DIRECTIONS[i].direction); actual != 1 {
+				t.Errorf("Expected the scanner to find 1 match, found %d", actual)
+			}
+
+			if actual := part1Calculation(data); actual != 1 {
+				t.Errorf("Expected only one scanner to match, found %d matches", actual)
+			}
+		})
+	}
+}
+
+func TestMatchesPattern2StartEnd(t *testing.T) {
+	tests := []struct {
+		start, end byte
+		expected   bool
+	}{
+		{start: 'M', end: 'S', expected: true},
+		{start: 'S', end: 'M', expected: true},
+		{start: 'M', end: 'M', expected: false},
+		{start: 'S', end: 'S', expected: false},
+		{start: 'X', end: 'S', expected: false},
+	}
+
+	for _, test := range tests {
+		if actual := matchesPattern2StartEnd(test.start, test.end); actual != test.expected {
+			t.Errorf("%c %c expected %t, got %t", test.start, test.end, test.expected, actual)
+		}
+	}
+}
+
+func TestPart2(t *testing.T) {
+	tests := []struct {
+		name     string
+		grid     string
+		expected int
+	}{
+		{name: "cross", grid: "M.S\n.A.\nM.S\n", expected: 1},
+		{name: "rotated cross", grid: "S.S\n.A.\nM.M\n", expected: 1},
+		{name: "same diagonal", grid: "M.M\n.A.\nS.M\n", expected: 0},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			data, err := parseData([]byte(test.grid))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if actual := part2Calculation(context.Background(), data); actual != test.expected {
+				t.Errorf("Expected %d, got %d", test.expected, actual)
+			}
+		})
+	}
+}
+
+func formatData(data ParsedData) []byte {
+	return []byte(data.letters.Format(func(b byte) string { return string([]byte{b}) }))
+}
+
+func FuzzParseData(f *testing.F) {
+	aoctest.Quiet(f)
+
+	f.Fuzz(func(t *testing.T, input []byte) {
+		data, err := parseData(input)
+		if err != nil {
+			return
+		}
+
+		// A CR in the grid is read back as part of a CRLF line ending
+		formatted := formatData(data)
+		if bytes.IndexByte(formatted, '\r') != -1 {
+			return
+		}
+
+		reparsed, err := parseData(formatted)
+		if err != nil {
+			t.Fatalf("Cannot parse the formatted data %q: %s", formatted, err)
+		}
+
+		if data.letters.Width() != reparsed.letters.Width() || data.letters.Height() != reparsed.letters.Height() {
+			t.Fatalf("Expected a %dx%d grid after a round trip, got %dx%d",
+				data.letters.Width(), data.letters.Height(), reparsed.letters.Width(), reparsed.letters.Height())
+		}
+
+		if reformatted := formatData(reparsed); !bytes.Equal(formatted, reformatted) {
+			t.Errorf("Expected %q after a round trip, got %q", formatted, reformatted)
+		}
+
+		part1Calculation(data)
+		part2Calculation(context.Background(), data)
+	})
+}
+
+func BenchmarkDay(b *testing.B) {
+	aoctest.Benchmark(b, Day())
+}
@@ -1,13 +1,15 @@
-package main
+package day05
 
 import (
-	"errors"
+	"bytes"
+	"context"
+	"embed"
 	"fmt"
-	"os"
 	"slices"
 
 	"github.com/charmbracelet/log"
-	"github.com/jessevdk/go-flags"
+	"github.com/djpiper28/advent-of-code-2024/aoc"
+	"github.com/djpiper28/advent-of-code-2024/aoc/tokenizer"
 )
 
 type Sequence struct {
@@ -27,82 +29,11 @@ const (
 	SEQUENCE_SEPERATOR  = ','
 )
 
-type ParsingState struct {
-	Data  []byte
-	Index int
-}
-
-func newParsingState(data []byte) *ParsingState {
-	return &ParsingState{
-		Data:  data,
-		Index: 0,
-	}
-}
-
-func (p *ParsingState) isEof() bool {
-	return p.Index >= len(p.Data)
-}
-
-func (p *ParsingState) current() byte {
-	return p.Data[p.Index]
-}
-
-func (p *ParsingState) next() bool {
-	p.Index++
-	return p.isEof()
-}
-
-func isDigit(b byte) bool {
-	return b >= '0' && b <= '9'
-}
-
-func (p *ParsingState) scanNumber() (int, error) {
-	if !isDigit(p.current()) {
-		return 0, errors.New("Not a digit")
-	}
-
-	number := 0
-	for isDigit(p.current()) && !p.isEof() {
-		number *= 10
-		number += int(p.current() - '0')
-		p.next()
-	}
-
-	return number, nil
-}
-
-func (p *ParsingState) scanNewLine() error {
-	if p.current() != '\n' {
-		return fmt.Errorf("Expected a new line, found %c", p.current())
-	}
-
-	p.next()
-	return nil
-}
-
-func (p *ParsingState) scanDependantSeperator() error {
-	if p.current() != DEPENDANT_SEPERATOR {
-		return fmt.Errorf("Expected a digit seperator, found %c", p.current())
-	}
-
-	p.next()
-	return nil
-}
-
-func (p *ParsingState) scanSequenceSeperator() error {
-	if p.current() != SEQUENCE_SEPERATOR {
-		return fmt.Errorf("Expected a sequence seperator, found %c", p.current())
-	}
-
-	p.next()
-	return nil
-}
-
-func (p *ParsingState) scanSequence() (Sequence, error) {
+func scanSequence(t *tokenizer.Tokenizer) (Sequence, error) {
 	sequence := make([]int, 0)
 
-	for !p.isEof() {
-		num, err := p.scanNumber()
+	for {
+		num, err := t.Int()
 		if err != nil {
 			log.Error("Expected a digit", "err", err)
 			return Sequence{}, err
@@ -110,25 +41,21 @@ func (p *ParsingState) scanSequence() (Sequence, error) {
 
 		sequence = append(sequence, num)
 
-		if p.isEof() {
+		if !t.Accept(SEQUENCE_SEPERATOR) {
 			break
 		}
+	}
 
-		if err = p.scanSequenceSeperator(); err != nil {
-			if err = p.scanNewLine(); err == nil {
-				break
-			} else {
-				log.Error("Expected a comma or a new line")
-				return Sequence{}, errors.New("Expected a comma or a new line")
-			}
-		}
+	if err := t.EndOfLine(); err != nil {
+		log.Error("Expected a comma or a new line", "err", err)
+		return Sequence{}, err
 	}
 
 	return Sequence{Data: sequence}, nil
 }
 
 func parseData(data []byte) (ParsedData, error) {
-	state := newParsingState(data)
+	t := tokenizer.New(bytes.NewReader(data))
 	ret := ParsedData{
 		NumberDependancies: make(map[int][]int),
 		Sequences:          make([]Sequence, 0),
@@ -136,50 +63,46 @@ func parseData(data []byte) (ParsedData, error) {
 	totalDependancies := 0
 
 	// Parse rules
-	for !state.isEof() {
+	for !t.EOF() {
 		// scan empty line - rules and sequence seperator
-		if err := state.scanNewLine(); err == nil {
+		if t.AtEndOfLine() {
+			t.AcceptNewLine()
 			break
 		}
 
-		dependant, err := state.scanNumber()
+		dependant, err := t.Int()
 		if err != nil {
 			log.Error("Expected a digit", "err", err)
 			return ret, err
 		}
 
-		if err = state.scanDependantSeperator(); err != nil {
+		if err = t.Expect(DEPENDANT_SEPERATOR); err != nil {
 			log.Error("Expected a digit seperator", "err", err)
 			return ret, err
 		}
 
-		num, err := state.scanNumber()
+		num, err := t.Int()
 		if err != nil {
 			log.Error("Expected a digit", "err", err)
 			return ret, err
 		}
 
-		if err = state.scanNewLine(); err != nil {
+		if err = t.EndOfLine(); err != nil {
 			log.Error("Expected a new line", "err", err)
 			return ret, err
 		}
 
-		deps, found := ret.NumberDependancies[num]
-		if found {
-			deps = append(deps, dependant)
-			ret.NumberDependancies[num] = deps
-		} else {
-			list := make([]int, 0)
-			list = append(list, dependant)
-			ret.NumberDependancies[num] = list
-		}
-
+		ret.NumberDependancies[num] = append(ret.NumberDependancies[num], dependant)
 		totalDependancies++
 	}
 
-	for !state.isEof() {
-		sequence, err := state.scanSequence()
+	for !t.EOF() {
+		if t.AtEndOfLine() {
+			t.AcceptNewLine()
+			continue
+		}
 
+		sequence, err := scanSequence(t)
 		if err != nil {
 			log.Error("Cannot scan sequence", "err", err)
 			return ret, err
@@ -188,6 +111,10 @@ func parseData(data []byte) (ParsedData, error) {
 		ret.Sequences = append(ret.Sequences, sequence)
 	}
 
+	if err := t.Err(); err != nil {
+		return ret, err
+	}
+
 	log.Info("Parsing meta data",
 		"NumberDependancies", len(ret.NumberDependancies),
 		"totalDependancies", totalDependancies,
@@ -226,18 +153,22 @@ func testSequence(sequence *Sequence, data *ParsedData) bool {
 	return true
 }
 
-func part1Calculation(data ParsedData) {
+func part1Calculation(ctx context.Context, data ParsedData) int {
 	total := 0
 	validSequences := 0
+	progress := aoc.StartProgress(ctx, "sequences", len(data.Sequences))
+	defer progress.Done()
 
 	for _, sequence := range data.Sequences {
+		progress.Add(1)
 		if testSequence(&sequence, &data) {
 			total += sequence.Data[len(sequence.Data)/2]
 			validSequences++
 		}
 	}
 
-	log.Info("Complete!", "output", total, "validvalidSequences", validSequences)
+	log.Info("Checked sequences", "validSequences", validSequences)
+	return total
 }
 
 func contains(arr []int, num int) bool {
@@ -250,11 +181,14 @@ func contains(arr []int, num int) bool {
 	return false
 }
 
-func part2Calculation(data ParsedData) {
+func part2Calculation(ctx context.Context, data ParsedData) (int, error) {
 	total := 0
 	validSequences := 0
+	progress := aoc.StartProgress(ctx, "sequences", len(data.Sequences))
+	defer progress.Done()
 
 	for _, sequence := range data.Sequences {
+		progress.Add(1)
 		if testSequence(&sequence, &data) {
 			continue
 		}
@@ -276,45 +210,33 @@ func part2Calculation(data ParsedData) {
 			total += sequence.Data[len(sequence.Data)/2]
 			validSequences++
 		} else {
-			log.Fatal("The sequence should be valid after sorting", "sequence", sequence)
+			return 0, fmt.Errorf("The sequence should be valid after sorting: %v", sequence.Data)
 		}
 	}
 
-	log.Info("Complete!", "output", total, "validvalidSequences", validSequences)
+	log.Info("Reordered sequences", "validSequences", validSequences)
+	return total, nil
 }
 
-func main() {
-	var opts struct {
-		Part2 bool `short:"p" long:"part" description:"Whether to calculate for part 2"`
-	}
+type solver struct{}
 
-	_, err := flags.Parse(&opts)
-	if err != nil {
-		log.Fatal("Cannot parse cli args", "err", err)
-	}
+func (solver) Parse(input []byte) (ParsedData, error) {
+	return parseData(input)
+}
 
-	if opts.Part2 {
-		log.Info("Part 2 of the problem")
-	} else {
-		log.Info("Part 1 of the problem")
-	}
+func (solver) Part1(ctx context.Context, data ParsedData) (any, error) {
+	return part1Calculation(ctx, data), nil
+}
 
-	log.Info("Reading data....")
-	bytes, err := os.ReadFile("input.txt")
-	if err != nil {
-		log.Fatal("Cannot read the data from the file")
-	}
+func (solver) Part2(ctx context.Context, data ParsedData) (any, error) {
+	return part2Calculation(ctx, data)
+}
 
-	log.Info("Parsing data...")
-	data, err := parseData(bytes)
-	if err != nil {
-		log.Fatal("Cannot parse data", "err", err)
-	}
+//go:embed examples
+var examples embed.FS
 
-	log.Info("Calculating...")
-	if opts.Part2 {
-		part2Calculation(data)
-	} else {
-		part1Calculation(data)
-	}
+func Day() *aoc.Day {
+	day := aoc.NewDay("day05", solver{})
+	day.Examples = examples
+	return day
 }
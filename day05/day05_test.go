@@ -0,0 +1,178 @@
+package day05
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+	"testing"
+
+	"github.com/djpiper28/advent-of-code-2024/aoc/aoctest"
+	"github.com/djpiper28/advent-of-code-2024/aoc/tokenizer"
+)
+
+func TestExample(t *testing.T) {
+	input, err := os.ReadFile("examples/example.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		part     func(context.Context, ParsedData) (any, error)
+		expected any
+	}{
+		{name: "part 1", part: solver{}.Part1, expected: 143},
+		{name: "part 2", part: solver{}.Part2, expected: 123},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Part 2 sorts the sequences in place so each part gets its own copy
+			data, err := solver{}.Parse(input)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			answer, err := test.part(context.Background(), data)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if answer != test.expected {
+				t.Errorf("Expected %v, got %v", test.expected, answer)
+			}
+		})
+	}
+}
+
+func TestParseData(t *testing.T) {
+	data, err := parseData([]byte("47|53\n97|53\n\n75,47,53\n97,13\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	deps := data.NumberDependancies[53]
+	slices.Sort(deps)
+	if !slices.Equal(deps, []int{47, 97}) {
+		t.Errorf("Expected 53 to depend on 47 and 97, got %v", deps)
+	}
+
+	if len(data.Sequences) != 2 ||
+		!slices.Equal(data.Sequences[0].Data, []int{75, 47, 53}) ||
+		!slices.Equal(data.Sequences[1].Data, []int{97, 13}) {
+		t.Errorf("Unexpected sequences %v", data.Sequences)
+	}
+}
+
+func TestParseDataErrors(t *testing.T) {
+	tests := []struct {
+		name         string
+		input        string
+		line, column int
+	}{
+		{name: "missing rule seperator", input: "47,53\n\n1,2\n", line: 1, column: 3},
+		{name: "letter in rule", input: "47|a\n\n1,2\n", line: 1, column: 4},
+		{name: "bad sequence seperator", input: "47|53\n\n1;2\n", line: 3, column: 2},
+		{name: "trailing sequence seperator", input: "47|53\n\n1,2,\n", line: 3, column: 5},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := parseData([]byte(test.input))
+
+			var diagnostic *tokenizer.Diagnostic
+			if !errors.As(err, &diagnostic) {
+				t.Fatalf("Expected a diagnostic, got %v", err)
+			}
+
+			if diagnostic.Line != test.line || diagnostic.Column != test.column {
+				t.Errorf("Expected the error at %d:%d, got %d:%d", test.line, test.column, diagnostic.Line, diagnostic.Column)
+			}
+		})
+	}
+}
+
+func TestTestSequence(t *testing.T) {
+	data := ParsedData{NumberDependancies: map[int][]int{
+		53: {47},
+		13: {97},
+	}}
+
+	tests := []struct {
+		name     string
+		sequence []int
+		expected bool
+	}{
+		{name: "ordered", sequence: []int{47, 53}, expected: true},
+		{name: "out of order", sequence: []int{53, 47}, expected: false},
+		{name: "dependency not in sequence", sequence: []int{53, 13}, expected: true},
+		{name: "no rules", sequence: []int{1, 2, 3}, expected: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			sequence := Sequence{Data: test.sequence}
+			if actual := testSequence(&sequence, &data); actual != test.expected {
+				t.Errorf("Expected %t, got %t", test.expected, actual)
+			}
+		})
+	}
+}
+
+func formatData(data ParsedData) []byte {
+	var buffer bytes.Buffer
+	for _, num := range slices.Sorted(maps.Keys(data.NumberDependancies)) {
+		for _, dependant := range data.NumberDependancies[num] {
+			fmt.Fprintf(&buffer, "%d%c%d\n", dependant, DEPENDANT_SEPERATOR, num)
+		}
+	}
+
+	buffer.WriteByte('\n')
+	for _, sequence := range data.Sequences {
+		for i, num := range sequence.Data {
+			if i > 0 {
+				buffer.WriteByte(SEQUENCE_SEPERATOR)
+			}
+			fmt.Fprint(&buffer, num)
+		}
+		buffer.WriteByte('\n')
+	}
+
+	return buffer.Bytes()
+}
+
+func FuzzParseData(f *testing.F) {
+	aoctest.Quiet(f)
+
+	f.Fuzz(func(t *testing.T, input []byte) {
+		data, err := parseData(input)
+		if err != nil {
+			return
+		}
+
+		formatted := formatData(data)
+		reparsed, err := parseData(formatted)
+		if err != nil {
+			t.Fatalf("Cannot parse the formatted data %q: %s", formatted, err)
+		}
+
+		if !maps.EqualFunc(data.NumberDependancies, reparsed.NumberDependancies, slices.Equal) {
+			t.Errorf("Expected the rules %v after a round trip, got %v", data.NumberDependancies, reparsed.NumberDependancies)
+		}
+
+		equal := slices.EqualFunc(data.Sequences, reparsed.Sequences, func(a, b Sequence) bool {
+			return slices.Equal(a.Data, b.Data)
+		})
+		if !equal {
+			t.Errorf("Expected the sequences %v after a round trip, got %v", data.Sequences, reparsed.Sequences)
+		}
+	})
+}
+
+func BenchmarkDay(b *testing.B) {
+	aoctest.Benchmark(b, Day())
+}
@@ -1,13 +1,16 @@
-package main
+package day06
 
 import (
+	"context"
+	"embed"
 	"errors"
 	"fmt"
-	"os"
+	"runtime"
 	"sync"
 
 	"github.com/charmbracelet/log"
-	"github.com/jessevdk/go-flags"
+	"github.com/djpiper28/advent-of-code-2024/aoc"
+	"github.com/djpiper28/advent-of-code-2024/aoc/grid"
 )
 
 type MapTile byte
@@ -18,135 +21,68 @@ const (
 	StartingLocation         = '^'
 )
 
-type Direction int
-
-const (
-	DirectionUp Direction = iota
-	DirectionRight
-	DirectionDown
-	DirectionLeft
-)
-
-type Vec2 struct {
-	X, Y int
-}
-
 type ParsedData struct {
-	Grid      [][]MapTile
-	Location  Vec2
-	Direction Direction
+	Grid      *grid.Grid[MapTile]
+	Location  grid.Vec2
+	Direction grid.Direction
 }
 
 func (p *ParsedData) clone() *ParsedData {
-	ret := &ParsedData{Location: p.Location, Direction: p.Direction, Grid: make([][]MapTile, 0)}
-
-	for _, line := range p.Grid {
-		lineCopy := make([]MapTile, len(line))
-		copy(lineCopy, line)
-
-		ret.Grid = append(ret.Grid, lineCopy)
-	}
-
-	return ret
+	return &ParsedData{Location: p.Location, Direction: p.Direction, Grid: p.Grid.Clone()}
 }
 
 func parseData(data []byte) (ParsedData, error) {
-	ret := ParsedData{Grid: make([][]MapTile, 0), Direction: DirectionUp}
-
-	line := make([]MapTile, 0)
-	x := 0
-	y := 0
-
-	for _, b := range data {
-		if b == '\n' {
-			y++
-			x = 0
-
-			if len(ret.Grid) > 1 {
-				if len(line) != len(ret.Grid[0]) {
-					return ret, errors.New("There grid is not a rectangle")
-				}
+	ret := ParsedData{Direction: grid.DirectionUp}
+	foundGuard := false
+
+	tiles, err := grid.Parse(data, func(b byte, position grid.Vec2) (MapTile, error) {
+		switch b {
+		case StartingLocation:
+			if foundGuard {
+				return MapTileEmpty, errors.New("There is more than one guard")
 			}
 
-			ret.Grid = append(ret.Grid, line)
-			line = make([]MapTile, 0)
-		} else {
-			switch b {
-			case StartingLocation:
-				ret.Location.X = x
-				ret.Location.Y = y
-				log.Info("Guard starting at", "x", x, "y", y)
-
-				b = byte(MapTileEmpty)
-				fallthrough
-			case byte(MapTileWall):
-				fallthrough
-			case byte(MapTileEmpty):
-				line = append(line, MapTile(b))
-			default:
-				return ret, fmt.Errorf("Unrecognised char %c", b)
-			}
-
-			x++
+			foundGuard = true
+			ret.Location = position
+			log.Info("Guard starting at", "x", position.X, "y", position.Y)
+			return MapTileEmpty, nil
+		case byte(MapTileWall), byte(MapTileEmpty):
+			return MapTile(b), nil
+		default:
+			return MapTileEmpty, fmt.Errorf("Unrecognised char %c", b)
 		}
+	})
+	if err != nil {
+		return ret, err
 	}
 
+	if !foundGuard {
+		return ret, errors.New("Cannot find the guard's starting location")
+	}
+
+	ret.Grid = tiles
 	return ret, nil
 }
 
 func (p *ParsedData) rotate() {
-	p.Direction++
-	p.Direction %= 4
+	p.Direction = p.Direction.RotateRight()
 }
 
-func (p *ParsedData) canMoveTo(X, Y int) bool {
-	return p.Grid[Y][X] == MapTileEmpty
+func (p *ParsedData) canMoveTo(position grid.Vec2) bool {
+	return p.Grid.At(position) == MapTileEmpty
 }
 
 func (p *ParsedData) tryMoveGuard() (bool, error) {
-	switch p.Direction {
-	case DirectionUp:
-		if p.Location.Y-1 < 0 {
-			return true, nil
-		}
-
-		if p.canMoveTo(p.Location.X, p.Location.Y-1) {
-			p.Location.Y--
-		} else {
-			return false, errors.New("There is a wall in the way of the guard")
-		}
-	case DirectionDown:
-		if p.Location.Y+1 >= len(p.Grid) {
-			return true, nil
-		}
-
-		if p.canMoveTo(p.Location.X, p.Location.Y+1) {
-			p.Location.Y++
-		} else {
-			return false, errors.New("There is a wall in the way of the guard")
-		}
-	case DirectionLeft:
-		if p.Location.X-1 < 0 {
-			return true, nil
-		}
-
-		if p.canMoveTo(p.Location.X-1, p.Location.Y) {
-			p.Location.X--
-		} else {
-			return false, errors.New("There is a wall in the way of the guard")
-		}
-	case DirectionRight:
-		if p.Location.X+1 >= len(p.Grid[0]) {
-			return true, nil
-		}
+	next := p.Location.Add(p.Direction.Vec())
+	if !p.Grid.InBounds(next) {
+		return true, nil
+	}
 
-		if p.canMoveTo(p.Location.X+1, p.Location.Y) {
-			p.Location.X++
-		} else {
-			return false, errors.New("There is a wall in the way of the guard")
-		}
+	if !p.canMoveTo(next) {
+		return false, errors.New("There is a wall in the way of the guard")
 	}
 
+	p.Location = next
 	return false, nil
 }
 
@@ -173,25 +109,36 @@ func (p *ParsedData) moveGuard() bool {
 }
 
 type StateSeen struct {
-	Location  Vec2
-	Direction Direction
+	Location  grid.Vec2
+	Direction grid.Direction
 }
 
-func (p *ParsedData) guardPositions() (int, error) {
+var ErrGuardLost = errors.New("The guard is lost")
+
+// How many steps the guard takes between checks for cancellation
+const CANCEL_CHECK_STEPS = 1024
+
+// walk moves the guard until they leave the map and returns every position
+// that they visited
+func (p *ParsedData) walk(ctx context.Context) (map[grid.Vec2]bool, error) {
 	states := make(map[StateSeen]bool)
-	positions := make(map[Vec2]bool)
+	positions := make(map[grid.Vec2]bool)
+
+	for steps := 1; ; steps++ {
+		if steps%CANCEL_CHECK_STEPS == 0 && ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
 
-  for {
 		state := StateSeen{Location: p.Location, Direction: p.Direction}
 
 		_, found := states[state]
 		if found {
-			log.Warn("The guard is in a loop - he will never leave the lab.",
+			log.Debug("The guard is in a loop - he will never leave the lab.",
 				"location", p.Location,
 				"direction", p.Direction,
 				"positions", len(positions),
 				"states", len(states))
-			return 0, errors.New("The guard is lost")
+			return nil, ErrGuardLost
 		}
 
 		states[state] = true
@@ -204,91 +151,101 @@ func (p *ParsedData) guardPositions() (int, error) {
 		}
 	}
 
-	return len(positions), nil
+	return positions, nil
 }
 
-func part1Calculation(data ParsedData) {
-	count, err := data.guardPositions()
+func (p *ParsedData) guardPositions(ctx context.Context) (int, error) {
+	positions, err := p.walk(ctx)
 	if err != nil {
-		log.Fatal("Guard is unable to escape", "err", err)
+		return 0, err
 	}
 
-	log.Info("Complete", "output", count)
-	return
+	return len(positions), nil
 }
 
-func part2Calculation(data ParsedData) {
-	positions := make(map[Vec2]bool)
+func part1Calculation(ctx context.Context, data ParsedData) (int, error) {
+	count, err := data.guardPositions(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("Guard is unable to escape: %w", err)
+	}
 
-  p := data.clone()
-  for {
-		positions[p.Location] = true
+	return count, nil
+}
 
-		exited := p.moveGuard()
-		if exited {
-			break
-		}
+func part2Calculation(ctx context.Context, data ParsedData) (int, error) {
+	positions, err := data.clone().walk(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("Guard is unable to escape: %w", err)
 	}
 
-  var wg sync.WaitGroup
-  var lock sync.Mutex
-  total := 0
-
-  log.Info("Inserting obstacles", "obstacles", len(positions))
+	var wg sync.WaitGroup
+	var lock sync.Mutex
+	total := 0
+	obstacles := make(chan grid.Vec2)
+
+	log.Info("Inserting obstacles", "obstacles", len(positions))
+	progress := aoc.StartProgress(ctx, "obstacles", len(positions))
+	defer progress.Done()
+
+	for i := 0; i < runtime.NumCPU(); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			for position := range obstacles {
+				newGrid := data.clone()
+				newGrid.Grid.Set(position, MapTileWall)
+
+				_, err := newGrid.guardPositions(ctx)
+				if errors.Is(err, ErrGuardLost) {
+					lock.Lock()
+					total++
+					lock.Unlock()
+				}
+				progress.Add(1)
+			}
+		}()
+	}
 
-  for position := range positions {
-    wg.Add(1)
-    go func() {
-      defer wg.Done()
+insert:
+	for position := range positions {
+		select {
+		case obstacles <- position:
+		case <-ctx.Done():
+			break insert
+		}
+	}
 
-      newGrid := data.clone()
-      newGrid.Grid[position.Y][position.X] = MapTileWall
+	close(obstacles)
+	wg.Wait()
 
-      _, err := newGrid.guardPositions()
-      if err != nil {
-        lock.Lock()
-        defer lock.Unlock()
-        total++
-      }
-    }()
-  }
+	if err = ctx.Err(); err != nil {
+		return 0, err
+	}
 
-  wg.Wait()
-  log.Info("Completed brute force", "output", total)
+	log.Info("Completed brute force")
+	return total, nil
 }
 
-func main() {
-	var opts struct {
-		Part2 bool `short:"p" long:"part" description:"Whether to calculate for part 2"`
-	}
+type solver struct{}
 
-	_, err := flags.Parse(&opts)
-	if err != nil {
-		log.Fatal("Cannot parse cli args", "err", err)
-	}
+func (solver) Parse(input []byte) (ParsedData, error) {
+	return parseData(input)
+}
 
-	if opts.Part2 {
-		log.Info("Part 2 of the problem")
-	} else {
-		log.Info("Part 1 of the problem")
-	}
+func (solver) Part1(ctx context.Context, data ParsedData) (any, error) {
+	return part1Calculation(ctx, data)
+}
 
-	log.Info("Reading data....")
-	bytes, err := os.ReadFile("input.txt")
-	if err != nil {
-		log.Fatal("Cannot read the data from the file")
-	}
+func (solver) Part2(ctx context.Context, data ParsedData) (any, error) {
+	return part2Calculation(ctx, data)
+}
 
-	log.Info("Parsing data...")
-	data, err := parseData(bytes)
-	if err != nil {
-		log.Fatal("Cannot parse data", "err", err)
-	}
+//go:embed examples
+var examples embed.FS
 
-	log.Info("Calculating...")
-	if opts.Part2 {
-		part2Calculation(data)
-	} else {
-		part1Calculation(data)
-	}
+func Day() *aoc.Day {
+	day := aoc.NewDay("day06", solver{})
+	day.Examples = examples
+	return day
 }
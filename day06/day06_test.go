@@ -0,0 +1,196 @@
+package day06
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/djpiper28/advent-of-code-2024/aoc/aoctest"
+	"github.com/djpiper28/advent-of-code-2024/aoc/grid"
+	"github.com/djpiper28/advent-of-code-2024/aoc/tokenizer"
+)
+
+func TestExample(t *testing.T) {
+	input, err := os.ReadFile("examples/example.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := solver{}.Parse(input)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		part     func(context.Context, ParsedData) (any, error)
+		expected any
+	}{
+		{name: "part 1", part: solver{}.Part1, expected: 41},
+		{name: "part 2", part: solver{}.Part2, expected: 6},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			answer, err := test.part(context.Background(), data)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if answer != test.expected {
+				t.Errorf("Expected %v, got %v", test.expected, answer)
+			}
+		})
+	}
+}
+
+func TestParseData(t *testing.T) {
+	data, err := parseData([]byte("..#\n.^.\n...\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if data.Location != (grid.Vec2{X: 1, Y: 1}) {
+		t.Errorf("Expected the guard at 1,1, got %v", data.Location)
+	}
+
+	if data.Direction != grid.DirectionUp {
+		t.Errorf("Expected the guard to face up, got %v", data.Direction)
+	}
+
+	if data.Grid.At(grid.Vec2{X: 1, Y: 1}) != MapTileEmpty || data.Grid.At(grid.Vec2{X: 2, Y: 0}) != MapTileWall {
+		t.Errorf("Unexpected grid\n%s", data.Grid.Format(func(tile MapTile) string { return string(tile) }))
+	}
+
+	_, err = parseData([]byte("...\n..x\n"))
+
+	var diagnostic *tokenizer.Diagnostic
+	if !errors.As(err, &diagnostic) {
+		t.Fatalf("Expected a diagnostic for an unrecognised char, got %v", err)
+	}
+
+	if diagnostic.Line != 2 || diagnostic.Column != 3 {
+		t.Errorf("Expected the error at 2:3, got %d:%d", diagnostic.Line, diagnostic.Column)
+	}
+}
+
+func TestGuardPositions(t *testing.T) {
+	tests := []struct {
+		name          string
+		grid          string
+		expected      int
+		expectedError bool
+	}{
+		{name: "walks straight out", grid: "...\n...\n.^.\n", expected: 3},
+		{name: "turns right at a wall", grid: ".#.\n...\n.^.\n", expected: 3},
+		{name: "loops forever", grid: ".#..\n...#\n#^..\n..#.\n", expectedError: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			data, err := parseData([]byte(test.grid))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			actual, err := data.guardPositions(context.Background())
+			if test.expectedError {
+				if err == nil {
+					t.Fatal("Expected the guard to be stuck in a loop")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if actual != test.expected {
+				t.Errorf("Expected %d positions, got %d", test.expected, actual)
+			}
+		})
+	}
+}
+
+func TestPart2Cancelled(t *testing.T) {
+	input, err := os.ReadFile("examples/example.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := parseData(input)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err = part2Calculation(ctx, data); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected the brute force to be cancelled, got %v", err)
+	}
+}
+
+func TestClone(t *testing.T) {
+	data, err := parseData([]byte("...\n.^.\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	clone := data.clone()
+	clone.Grid.Set(grid.Vec2{X: 0, Y: 0}, MapTileWall)
+	clone.Location.X++
+
+	if data.Grid.At(grid.Vec2{X: 0, Y: 0}) != MapTileEmpty || data.Location.X != 1 {
+		t.Error("Modifying the clone changed the original")
+	}
+}
+
+func formatData(data ParsedData) []byte {
+	var buffer bytes.Buffer
+	for y := 0; y < data.Grid.Height(); y++ {
+		for x := 0; x < data.Grid.Width(); x++ {
+			position := grid.Vec2{X: x, Y: y}
+			if position == data.Location {
+				buffer.WriteByte(StartingLocation)
+			} else {
+				buffer.WriteByte(byte(data.Grid.At(position)))
+			}
+		}
+		buffer.WriteByte('\n')
+	}
+
+	return buffer.Bytes()
+}
+
+func FuzzParseData(f *testing.F) {
+	aoctest.Quiet(f)
+
+	f.Fuzz(func(t *testing.T, input []byte) {
+		data, err := parseData(input)
+		if err != nil {
+			return
+		}
+
+		formatted := formatData(data)
+		reparsed, err := parseData(formatted)
+		if err != nil {
+			t.Fatalf("Cannot parse the formatted data %q: %s", formatted, err)
+		}
+
+		if data.Location != reparsed.Location || data.Direction != reparsed.Direction {
+			t.Errorf("Expected the guard at %v facing %v after a round trip, got %v facing %v",
+				data.Location, data.Direction, reparsed.Location, reparsed.Direction)
+		}
+
+		if reformatted := formatData(reparsed); !bytes.Equal(formatted, reformatted) {
+			t.Errorf("Expected %q after a round trip, got %q", formatted, reformatted)
+		}
+	})
+}
+
+func BenchmarkDay(b *testing.B) {
+	aoctest.Benchmark(b, Day())
+}